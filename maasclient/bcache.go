@@ -0,0 +1,539 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	BcacheCacheSetsAPIPathFormat = "/nodes/%s/bcache-cache-sets/"
+	BcacheCacheSetAPIPathFormat  = "/nodes/%s/bcache-cache-set/%d/"
+	BcachesAPIPathFormat         = "/nodes/%s/bcaches/"
+	BcacheAPIPathFormat          = "/nodes/%s/bcache/%d/"
+)
+
+// BcacheCacheSets manages the bcache cache sets of a machine
+type BcacheCacheSets interface {
+	List(ctx context.Context) ([]BcacheCacheSet, error)
+	BcacheCacheSet(id int) BcacheCacheSet
+	Builder() BcacheCacheSetBuilder
+}
+
+// BcacheCacheSet represents a cache set built on an SSD block device or partition
+type BcacheCacheSet interface {
+	Get(ctx context.Context) (BcacheCacheSet, error)
+	Delete(ctx context.Context) error
+	Modifier() BcacheCacheSetModifier
+	ID() int
+	Name() string
+	SystemID() string
+	// CacheDevice returns the block device or partition holding the cache
+	CacheDevice() BlockDevice
+}
+
+// BcacheCacheSetBuilder takes exactly one of a cache device or a cache partition
+type BcacheCacheSetBuilder interface {
+	WithCacheDevice(id int) BcacheCacheSetBuilder
+	WithCachePartition(id int) BcacheCacheSetBuilder
+	Create(ctx context.Context) (BcacheCacheSet, error)
+}
+
+type BcacheCacheSetModifier interface {
+	SetCacheDevice(id int) BcacheCacheSetModifier
+	SetCachePartition(id int) BcacheCacheSetModifier
+	Update(ctx context.Context) (BcacheCacheSet, error)
+}
+
+// Bcaches manages the bcache devices of a machine
+type Bcaches interface {
+	List(ctx context.Context) ([]Bcache, error)
+	Bcache(id int) Bcache
+	Builder() BcacheBuilder
+}
+
+// Bcache represents a backing device accelerated by a cache set
+type Bcache interface {
+	Get(ctx context.Context) (Bcache, error)
+	Delete(ctx context.Context) error
+	Modifier() BcacheModifier
+	ID() int
+	UUID() string
+	Name() string
+	SystemID() string
+	// Size returns the size of the bcache device in bytes
+	Size() int64
+	// CacheMode returns one of the CacheMode* constants
+	CacheMode() string
+	BackingDevice() BlockDevice
+	CacheSet() BcacheCacheSet
+	// VirtualDevice returns the block device MAAS exposes for the bcache
+	VirtualDevice() BlockDevice
+}
+
+// BcacheBuilder takes one of a backing device or a backing partition
+type BcacheBuilder interface {
+	WithName(name string) BcacheBuilder
+	WithUUID(uuid string) BcacheBuilder
+	WithBackingDevice(id int) BcacheBuilder
+	WithBackingPartition(id int) BcacheBuilder
+	WithCacheSet(id int) BcacheBuilder
+	// WithCacheMode sets one of the CacheMode* constants
+	WithCacheMode(mode string) BcacheBuilder
+	Create(ctx context.Context) (Bcache, error)
+}
+
+type BcacheModifier interface {
+	SetName(name string) BcacheModifier
+	SetUUID(uuid string) BcacheModifier
+	SetBackingDevice(id int) BcacheModifier
+	SetBackingPartition(id int) BcacheModifier
+	SetCacheSet(id int) BcacheModifier
+	SetCacheMode(mode string) BcacheModifier
+	Update(ctx context.Context) (Bcache, error)
+}
+
+type bcacheCacheSets struct {
+	Controller
+	systemID string
+}
+
+func (cs *bcacheCacheSets) List(ctx context.Context) ([]BcacheCacheSet, error) {
+	res, err := cs.client.Get(ctx, cs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*bcacheCacheSet
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bcacheCacheSetStructSliceToInterface(obj, cs.client), nil
+}
+
+func (cs *bcacheCacheSets) BcacheCacheSet(id int) BcacheCacheSet {
+	return bcacheCacheSetStructToInterface(&bcacheCacheSet{id: id, systemID: cs.systemID}, cs.client)
+}
+
+func (cs *bcacheCacheSets) Builder() BcacheCacheSetBuilder {
+	cs.params.Reset()
+	return cs
+}
+
+func (cs *bcacheCacheSets) WithCacheDevice(id int) BcacheCacheSetBuilder {
+	cs.params.Set(CacheDeviceKey, strconv.Itoa(id))
+	return cs
+}
+
+func (cs *bcacheCacheSets) WithCachePartition(id int) BcacheCacheSetBuilder {
+	cs.params.Set(CachePartitionKey, strconv.Itoa(id))
+	return cs
+}
+
+func (cs *bcacheCacheSets) Create(ctx context.Context) (BcacheCacheSet, error) {
+	res, err := cs.client.Post(ctx, cs.apiPath, cs.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *bcacheCacheSet
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bcacheCacheSetStructToInterface(obj, cs.client), nil
+}
+
+func bcacheCacheSetStructSliceToInterface(in []*bcacheCacheSet, client Client) []BcacheCacheSet {
+	var out []BcacheCacheSet
+	for _, s := range in {
+		out = append(out, bcacheCacheSetStructToInterface(s, client))
+	}
+	return out
+}
+
+func bcacheCacheSetStructToInterface(in *bcacheCacheSet, client Client) BcacheCacheSet {
+	if in == nil {
+		return nil
+	}
+	in.client = client
+	in.apiPath = fmt.Sprintf(BcacheCacheSetAPIPathFormat, in.systemID, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type bcacheCacheSet struct {
+	Controller
+	id          int
+	name        string
+	systemID    string
+	cacheDevice *blockDevice
+}
+
+func (s *bcacheCacheSet) Get(ctx context.Context) (BcacheCacheSet, error) {
+	res, err := s.client.Get(ctx, s.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, unMarshalJson(res, &s)
+}
+
+func (s *bcacheCacheSet) Delete(ctx context.Context) error {
+	res, err := s.client.Delete(ctx, s.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (s *bcacheCacheSet) Modifier() BcacheCacheSetModifier {
+	s.params.Reset()
+	return s
+}
+
+func (s *bcacheCacheSet) SetCacheDevice(id int) BcacheCacheSetModifier {
+	s.params.Set(CacheDeviceKey, strconv.Itoa(id))
+	return s
+}
+
+func (s *bcacheCacheSet) SetCachePartition(id int) BcacheCacheSetModifier {
+	s.params.Set(CachePartitionKey, strconv.Itoa(id))
+	return s
+}
+
+func (s *bcacheCacheSet) Update(ctx context.Context) (BcacheCacheSet, error) {
+	res, err := s.client.PutParams(ctx, s.apiPath, s.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return s, unMarshalJson(res, &s)
+}
+
+func (s *bcacheCacheSet) ID() int {
+	return s.id
+}
+
+func (s *bcacheCacheSet) Name() string {
+	return s.name
+}
+
+func (s *bcacheCacheSet) SystemID() string {
+	return s.systemID
+}
+
+func (s *bcacheCacheSet) CacheDevice() BlockDevice {
+	return blockDeviceToInterface(s.cacheDevice)
+}
+
+func (s *bcacheCacheSet) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID          int          `json:"id"`
+		Name        string       `json:"name"`
+		SystemID    string       `json:"system_id"`
+		CacheDevice *blockDevice `json:"cache_device"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	s.id = des.ID
+	s.name = des.Name
+	s.systemID = des.SystemID
+	s.cacheDevice = des.CacheDevice
+
+	return nil
+}
+
+type bcaches struct {
+	Controller
+	systemID string
+}
+
+func (bs *bcaches) List(ctx context.Context) ([]Bcache, error) {
+	res, err := bs.client.Get(ctx, bs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*bcache
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bcacheStructSliceToInterface(obj, bs.client), nil
+}
+
+func (bs *bcaches) Bcache(id int) Bcache {
+	return bcacheStructToInterface(&bcache{id: id, systemID: bs.systemID}, bs.client)
+}
+
+func (bs *bcaches) Builder() BcacheBuilder {
+	bs.params.Reset()
+	return bs
+}
+
+func (bs *bcaches) WithName(name string) BcacheBuilder {
+	bs.params.Set(NameKey, name)
+	return bs
+}
+
+func (bs *bcaches) WithUUID(uuid string) BcacheBuilder {
+	bs.params.Set(UUIDKey, uuid)
+	return bs
+}
+
+func (bs *bcaches) WithBackingDevice(id int) BcacheBuilder {
+	bs.params.Set(BackingDeviceKey, strconv.Itoa(id))
+	return bs
+}
+
+func (bs *bcaches) WithBackingPartition(id int) BcacheBuilder {
+	bs.params.Set(BackingPartitionKey, strconv.Itoa(id))
+	return bs
+}
+
+func (bs *bcaches) WithCacheSet(id int) BcacheBuilder {
+	bs.params.Set(CacheSetKey, strconv.Itoa(id))
+	return bs
+}
+
+func (bs *bcaches) WithCacheMode(mode string) BcacheBuilder {
+	bs.params.Set(CacheModeKey, mode)
+	return bs
+}
+
+func (bs *bcaches) Create(ctx context.Context) (Bcache, error) {
+	if !isValidCacheMode(bs.params.Values().Get(CacheModeKey)) {
+		return nil, fmt.Errorf("invalid cache mode %q", bs.params.Values().Get(CacheModeKey))
+	}
+
+	res, err := bs.client.Post(ctx, bs.apiPath, bs.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *bcache
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bcacheStructToInterface(obj, bs.client), nil
+}
+
+func isValidCacheMode(mode string) bool {
+	switch mode {
+	case CacheModeWriteBack, CacheModeWriteThrough, CacheModeWriteAround:
+		return true
+	}
+	return false
+}
+
+func bcacheStructSliceToInterface(in []*bcache, client Client) []Bcache {
+	var out []Bcache
+	for _, b := range in {
+		out = append(out, bcacheStructToInterface(b, client))
+	}
+	return out
+}
+
+func bcacheStructToInterface(in *bcache, client Client) Bcache {
+	in.client = client
+	in.apiPath = fmt.Sprintf(BcacheAPIPathFormat, in.systemID, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type bcache struct {
+	Controller
+	id            int
+	uuid          string
+	name          string
+	systemID      string
+	size          int64
+	cacheMode     string
+	backingDevice *blockDevice
+	cacheSet      *bcacheCacheSet
+	virtualDevice *blockDevice
+}
+
+func (b *bcache) Get(ctx context.Context) (Bcache, error) {
+	res, err := b.client.Get(ctx, b.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, unMarshalJson(res, &b)
+}
+
+func (b *bcache) Delete(ctx context.Context) error {
+	res, err := b.client.Delete(ctx, b.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (b *bcache) Modifier() BcacheModifier {
+	b.params.Reset()
+	return b
+}
+
+func (b *bcache) SetName(name string) BcacheModifier {
+	b.params.Set(NameKey, name)
+	return b
+}
+
+func (b *bcache) SetUUID(uuid string) BcacheModifier {
+	b.params.Set(UUIDKey, uuid)
+	return b
+}
+
+func (b *bcache) SetBackingDevice(id int) BcacheModifier {
+	b.params.Set(BackingDeviceKey, strconv.Itoa(id))
+	return b
+}
+
+func (b *bcache) SetBackingPartition(id int) BcacheModifier {
+	b.params.Set(BackingPartitionKey, strconv.Itoa(id))
+	return b
+}
+
+func (b *bcache) SetCacheSet(id int) BcacheModifier {
+	b.params.Set(CacheSetKey, strconv.Itoa(id))
+	return b
+}
+
+func (b *bcache) SetCacheMode(mode string) BcacheModifier {
+	b.params.Set(CacheModeKey, mode)
+	return b
+}
+
+func (b *bcache) Update(ctx context.Context) (Bcache, error) {
+	if mode := b.params.Values().Get(CacheModeKey); mode != "" && !isValidCacheMode(mode) {
+		return nil, fmt.Errorf("invalid cache mode %q", mode)
+	}
+
+	res, err := b.client.PutParams(ctx, b.apiPath, b.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return b, unMarshalJson(res, &b)
+}
+
+func (b *bcache) ID() int {
+	return b.id
+}
+
+func (b *bcache) UUID() string {
+	return b.uuid
+}
+
+func (b *bcache) Name() string {
+	return b.name
+}
+
+func (b *bcache) SystemID() string {
+	return b.systemID
+}
+
+func (b *bcache) Size() int64 {
+	return b.size
+}
+
+func (b *bcache) CacheMode() string {
+	return b.cacheMode
+}
+
+func (b *bcache) BackingDevice() BlockDevice {
+	return blockDeviceToInterface(b.backingDevice)
+}
+
+func (b *bcache) CacheSet() BcacheCacheSet {
+	return bcacheCacheSetStructToInterface(b.cacheSet, b.client)
+}
+
+func (b *bcache) VirtualDevice() BlockDevice {
+	return blockDeviceToInterface(b.virtualDevice)
+}
+
+func (b *bcache) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID            int             `json:"id"`
+		UUID          string          `json:"uuid"`
+		Name          string          `json:"name"`
+		SystemID      string          `json:"system_id"`
+		Size          int64           `json:"size"`
+		CacheMode     string          `json:"cache_mode"`
+		BackingDevice *blockDevice    `json:"backing_device"`
+		CacheSet      *bcacheCacheSet `json:"cache_set"`
+		VirtualDevice *blockDevice    `json:"virtual_device"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	b.id = des.ID
+	b.uuid = des.UUID
+	b.name = des.Name
+	b.systemID = des.SystemID
+	b.size = des.Size
+	b.cacheMode = des.CacheMode
+	b.backingDevice = des.BackingDevice
+	b.cacheSet = des.CacheSet
+	b.virtualDevice = des.VirtualDevice
+
+	return nil
+}
+
+func newBcacheCacheSetsClient(client Client, systemID string) BcacheCacheSets {
+	return &bcacheCacheSets{
+		Controller: Controller{
+			client:  client,
+			apiPath: fmt.Sprintf(BcacheCacheSetsAPIPathFormat, systemID),
+			params:  ParamsBuilder(),
+		},
+		systemID: systemID,
+	}
+}
+
+func newBcachesClient(client Client, systemID string) Bcaches {
+	return &bcaches{
+		Controller: Controller{
+			client:  client,
+			apiPath: fmt.Sprintf(BcachesAPIPathFormat, systemID),
+			params:  ParamsBuilder(),
+		},
+		systemID: systemID,
+	}
+}
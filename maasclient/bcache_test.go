@@ -0,0 +1,85 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBcaches(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a Ready machine, an unused SSD partition and an unused HDD
+	systemID := "REPLACE_WITH_MACHINE_SYSTEM_ID"
+	cachePartitionID := 0
+	backingDeviceID := 0
+
+	t.Run("invalid cache mode", func(t *testing.T) {
+		res, err := c.Machines().Machine(systemID).Bcaches().
+			Builder().
+			WithCacheMode("writeonly").
+			Create(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("create cache set and bcache", func(t *testing.T) {
+		if systemID == "REPLACE_WITH_MACHINE_SYSTEM_ID" {
+			t.Skip("Please replace placeholder machine system ID and device IDs")
+			return
+		}
+
+		m := c.Machines().Machine(systemID)
+
+		cacheSet, err := m.BcacheCacheSets().Builder().
+			WithCachePartition(cachePartitionID).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, cacheSet)
+		assert.Equal(t, cacheSet.CacheDevice().ID(), cachePartitionID)
+		assert.True(t, cacheSet.CacheDevice().IsPartition())
+
+		res, err := m.Bcaches().Builder().
+			WithName("bcache-test").
+			WithBackingDevice(backingDeviceID).
+			WithCacheSet(cacheSet.ID()).
+			WithCacheMode(CacheModeWriteBack).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.CacheMode(), CacheModeWriteBack)
+		assert.Equal(t, res.BackingDevice().ID(), backingDeviceID)
+		assert.Equal(t, res.CacheSet().ID(), cacheSet.ID())
+		assert.NotNil(t, res.VirtualDevice())
+
+		res, err = res.Modifier().SetCacheMode(CacheModeWriteThrough).Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.CacheMode(), CacheModeWriteThrough)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+
+		err = cacheSet.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import "encoding/json"
+
+// BlockDevice is a read-only view of a block device or partition as embedded
+// in MAAS storage responses (RAID members, volume group devices, bcache
+// backing devices, virtual devices, ...)
+type BlockDevice interface {
+	ID() int
+	Name() string
+	// Type returns "physical", "virtual" or "partition"
+	Type() string
+	Path() string
+	UUID() string
+	// Size returns the size in bytes
+	Size() int64
+	// IsPartition returns true when the device is a partition rather than a whole block device
+	IsPartition() bool
+	// DeviceID returns the ID of the block device holding a partition, or 0 for block devices
+	DeviceID() int
+	Tags() []string
+}
+
+type blockDevice struct {
+	id       int
+	name     string
+	devType  string
+	path     string
+	uuid     string
+	size     int64
+	deviceID int
+	tags     []string
+}
+
+func (b *blockDevice) ID() int {
+	return b.id
+}
+
+func (b *blockDevice) Name() string {
+	return b.name
+}
+
+func (b *blockDevice) Type() string {
+	return b.devType
+}
+
+func (b *blockDevice) Path() string {
+	return b.path
+}
+
+func (b *blockDevice) UUID() string {
+	return b.uuid
+}
+
+func (b *blockDevice) Size() int64 {
+	return b.size
+}
+
+func (b *blockDevice) IsPartition() bool {
+	return b.devType == BlockDeviceTypePartition
+}
+
+func (b *blockDevice) DeviceID() int {
+	return b.deviceID
+}
+
+func (b *blockDevice) Tags() []string {
+	return b.tags
+}
+
+func (b *blockDevice) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID       int      `json:"id"`
+		Name     string   `json:"name"`
+		Type     string   `json:"type"`
+		Path     string   `json:"path"`
+		UUID     string   `json:"uuid"`
+		Size     int64    `json:"size"`
+		DeviceID int      `json:"device_id"`
+		Tags     []string `json:"tags"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	b.id = des.ID
+	b.name = des.Name
+	b.devType = des.Type
+	b.path = des.Path
+	b.uuid = des.UUID
+	b.size = des.Size
+	b.deviceID = des.DeviceID
+	b.tags = des.Tags
+
+	return nil
+}
+
+func blockDeviceSliceToInterface(in []*blockDevice) []BlockDevice {
+	var out []BlockDevice
+	for _, b := range in {
+		out = append(out, b)
+	}
+	return out
+}
+
+// blockDeviceToInterface avoids returning a typed nil inside a non-nil interface
+func blockDeviceToInterface(in *blockDevice) BlockDevice {
+	if in == nil {
+		return nil
+	}
+	return in
+}
@@ -197,6 +197,10 @@ type authenticatedClientSet struct {
 	networkInterfacesController NetworkInterfaces
 	ipAddressesController       IPAddresses
 	vmHostsController           VMHosts
+	fabricsController           Fabrics
+	ipRangesController          IPRanges
+	staticRoutesController      StaticRoutes
+	dnsResourceRecordController DNSResourceRecords
 }
 
 func (m *authenticatedClientSet) RackControllers() RackControllers {
@@ -259,6 +263,26 @@ func (m *authenticatedClientSet) VMHosts() VMHosts {
 	return m.vmHostsController
 }
 
+func (m *authenticatedClientSet) Fabrics() Fabrics {
+	return m.fabricsController
+}
+
+func (m *authenticatedClientSet) VLANs(fabricID int) VLANs {
+	return NewVLANsClient(m.client, fabricID)
+}
+
+func (m *authenticatedClientSet) IPRanges() IPRanges {
+	return m.ipRangesController
+}
+
+func (m *authenticatedClientSet) StaticRoutes() StaticRoutes {
+	return m.staticRoutesController
+}
+
+func (m *authenticatedClientSet) DNSResourceRecords() DNSResourceRecords {
+	return m.dnsResourceRecordController
+}
+
 func NewAuthenticatedClientSet(maasEndpoint, apiKey string, options ...func(client *authenticatedClientSet)) ClientSetInterface {
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402 : already addressed in PCP-3389
@@ -296,6 +320,10 @@ func NewAuthenticatedClientSet(maasEndpoint, apiKey string, options ...func(clie
 	clientSet.networkInterfacesController = NewNetworkInterfacesClient(client)
 	clientSet.ipAddressesController = NewIPAddressesClient(client)
 	clientSet.vmHostsController = NewVMHostsClient(client)
+	clientSet.fabricsController = NewFabricsClient(client)
+	clientSet.ipRangesController = NewIPRangesClient(client)
+	clientSet.staticRoutesController = NewStaticRoutesClient(client)
+	clientSet.dnsResourceRecordController = NewDNSResourceRecordsClient(client)
 
 	return clientSet
 }
@@ -19,16 +19,21 @@ package maasclient
 type ClientSetInterface interface {
 	BootResources() BootResources
 	DNSResources() DNSResources
+	DNSResourceRecords() DNSResourceRecords
 	Domains() Domains
+	Fabrics() Fabrics
 	IPAddresses() IPAddresses
+	IPRanges() IPRanges
 	Tags() Tags
 	Machines() Machines
 	NetworkInterfaces() NetworkInterfaces
 	RackControllers() RackControllers
 	ResourcePools() ResourcePools
 	Spaces() Spaces
+	StaticRoutes() StaticRoutes
 	Subnets() Subnets
 	Users() Users
+	VLANs(fabricID int) VLANs
 	Zones() Zones
 	SSHKeys() SSHKeys
 	VMHosts() VMHosts
@@ -18,6 +18,7 @@ package maasclient
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -48,10 +49,39 @@ func unMarshalJson(res *http.Response, v interface{}) error {
 			return json.Unmarshal(bodyBytes, v)
 		}
 		return nil
-	case res.StatusCode >= 300:
-		return fmt.Errorf("status: %d, message: %s", res.StatusCode, bodyBytes)
 	}
-	return fmt.Errorf("status: %d, message: %s", res.StatusCode, bodyBytes)
+	return &HTTPError{StatusCode: res.StatusCode, Body: bodyBytes}
+}
+
+// readBody returns the body of a successful response that isn't JSON
+func readBody(res *http.Response) ([]byte, error) {
+	defer res.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusNoContent && !statusAcceptable(res.StatusCode) {
+		return nil, &HTTPError{StatusCode: res.StatusCode, Body: bodyBytes}
+	}
+	return bodyBytes, nil
+}
+
+// HTTPError is returned when MAAS answers with a status other than success
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("status: %d, message: %s", e.StatusCode, e.Body)
+}
+
+// isNotFound returns true if err is a 404 response from MAAS
+func isNotFound(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
 }
 
 func statusAcceptable(status int) bool {
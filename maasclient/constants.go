@@ -61,22 +61,190 @@ const (
 	ModeKey            = "mode"
 	LinkIDKey          = "id"
 	ParentKey          = "parent"
+	ParentsKey         = "parents"
 	EphemeralDeployKey = "ephemeral_deploy"
+	DescriptionKey     = "description"
+	ClassTypeKey       = "class_type"
+	VIDKey             = "vid"
+	SpaceKey           = "space"
+	DHCPOnKey          = "dhcp_on"
+	PrimaryRackKey     = "primary_rack"
+	SecondaryRackKey   = "secondary_rack"
+	RelayVLANKey       = "relay_vlan"
+	CIDRKey            = "cidr"
+	FabricKey          = "fabric"
+	GatewayIPKey       = "gateway_ip"
+	DNSServersKey      = "dns_servers"
+	ManagedKey         = "managed"
+	AllowDNSKey        = "allow_dns"
+	AllowProxyKey      = "allow_proxy"
+	ActiveDiscoveryKey = "active_discovery"
+	RDNSModeKey        = "rdns_mode"
+	TypeKey            = "type"
+	MACKey             = "mac"
+	IPKey              = "ip"
+	StartIPKey         = "start_ip"
+	EndIPKey           = "end_ip"
+	SourceKey          = "source"
+	DestinationKey     = "destination"
+	MetricKey          = "metric"
+	AuthoritativeKey   = "authoritative"
+	TTLKey             = "ttl"
+	ForwardDNSKey      = "forward_dns_servers"
+	RRDataKey          = "rrdata"
+	DefinitionKey      = "definition"
+	KernelOptsKey      = "kernel_opts"
+	AddKey             = "add"
+	RemoveKey          = "remove"
+	OwnerKey           = "owner"
+
+	// user parameters
+	UsernameKey            = "username"
+	EmailKey               = "email"
+	PasswordKey            = "password"
+	IsSuperUserKey         = "is_superuser"
+	TransferResourcesToKey = "transfer_resources_to"
+
+	// SSH key parameters
+	KeyKey             = "key"
+	KeySourceKey       = "keysource"
+	UserKey            = "user"
+	KeySourceLaunchpad = "lp"
+	KeySourceGitHub    = "gh"
+
+	// network interface parameters
+	MTUKey                = "mtu"
+	VLANKey               = "vlan"
+	MACAddressKey         = "mac_address"
+	BondModeKey           = "bond_mode"
+	BondMIIMonKey         = "bond_miimon"
+	BondDownDelayKey      = "bond_downdelay"
+	BondUpDelayKey        = "bond_updelay"
+	BondLACPRateKey       = "bond_lacp_rate"
+	BondXmitHashPolicyKey = "bond_xmit_hash_policy"
+	AcceptRAKey           = "accept_ra"
+	AutoconfKey           = "autoconf"
+	BridgeSTPKey          = "bridge_stp"
+	BridgeFDKey           = "bridge_fd"
+	SingleTagKey          = "tag"
+	GatewayLinkIDKey      = "link_id"
+
+	// storage parameters
+	UUIDKey                  = "uuid"
+	LevelKey                 = "level"
+	BlockDevicesKey          = "block_devices"
+	PartitionsKey            = "partitions"
+	SpareDevicesKey          = "spare_devices"
+	SparePartitionsKey       = "spare_partitions"
+	AddBlockDevicesKey       = "add_block_devices"
+	RemoveBlockDevicesKey    = "remove_block_devices"
+	AddPartitionsKey         = "add_partitions"
+	RemovePartitionsKey      = "remove_partitions"
+	AddSpareDevicesKey       = "add_spare_devices"
+	RemoveSpareDevicesKey    = "remove_spare_devices"
+	AddSparePartitionsKey    = "add_spare_partitions"
+	RemoveSparePartitionsKey = "remove_spare_partitions"
+	CacheDeviceKey           = "cache_device"
+	CachePartitionKey        = "cache_partition"
+	BackingDeviceKey         = "backing_device"
+	BackingPartitionKey      = "backing_partition"
+	CacheSetKey              = "cache_set"
+	CacheModeKey             = "cache_mode"
 
 	// Network interface modes
 	ModeDHCP   = "dhcp"
 	ModeStatic = "static"
 	ModeLinkUp = "link_up"
 
+	// Subnet reverse DNS modes
+	RDNSModeDisabled = 0
+	RDNSModeEnabled  = 1
+	RDNSModeRFC2317  = 2
+
+	// DNS resource record types
+	RRTypeCNAME = "CNAME"
+	RRTypeTXT   = "TXT"
+	RRTypeMX    = "MX"
+	RRTypeSRV   = "SRV"
+	RRTypeNS    = "NS"
+	RRTypeSSHFP = "SSHFP"
+
+	// SSHFP algorithms and fingerprint types
+	SSHFPAlgorithmRSA     = 1
+	SSHFPAlgorithmDSA     = 2
+	SSHFPAlgorithmECDSA   = 3
+	SSHFPAlgorithmEd25519 = 4
+	SSHFPTypeSHA1         = 1
+	SSHFPTypeSHA256       = 2
+
+	// IP address allocation types
+	IPAllocTypeAuto         = 0
+	IPAllocTypeSticky       = 1
+	IPAllocTypeUserReserved = 4
+	IPAllocTypeDHCP         = 5
+	IPAllocTypeDiscovered   = 6
+
+	// IP range types
+	IPRangeTypeDynamic  = "dynamic"
+	IPRangeTypeReserved = "reserved"
+
+	// Bond modes
+	BondModeBalanceRR    = "balance-rr"
+	BondModeActiveBackup = "active-backup"
+	BondModeBalanceXOR   = "balance-xor"
+	BondModeBroadcast    = "broadcast"
+	BondMode8023AD       = "802.3ad"
+	BondModeBalanceTLB   = "balance-tlb"
+	BondModeBalanceALB   = "balance-alb"
+
+	// RAID levels
+	RAIDLevel0  = "raid-0"
+	RAIDLevel1  = "raid-1"
+	RAIDLevel5  = "raid-5"
+	RAIDLevel6  = "raid-6"
+	RAIDLevel10 = "raid-10"
+
+	// Bcache cache modes
+	CacheModeWriteBack    = "writeback"
+	CacheModeWriteThrough = "writethrough"
+	CacheModeWriteAround  = "writearound"
+
+	// Block device types
+	BlockDeviceTypePhysical  = "physical"
+	BlockDeviceTypeVirtual   = "virtual"
+	BlockDeviceTypePartition = "partition"
+
 	// Resource operations
-	Operation                 = "op"
-	OperationDeploy           = "deploy"
-	OperationWhoAmI           = "whoami"
-	OperationImportBootImages = "import_boot_images"
-	OperationReleaseMachine   = "release"
-	OperationAllocate         = "allocate"
-	OperationLinkSubnet       = "link_subnet"
-	OperationUnlinkSubnet     = "unlink_subnet"
-	OperationCreateBridge     = "create_bridge"
-	OperationReleaseIPAddress = "release"
+	Operation                     = "op"
+	OperationDeploy               = "deploy"
+	OperationWhoAmI               = "whoami"
+	OperationImportBootImages     = "import_boot_images"
+	OperationReleaseMachine       = "release"
+	OperationAllocate             = "allocate"
+	OperationLinkSubnet           = "link_subnet"
+	OperationUnlinkSubnet         = "unlink_subnet"
+	OperationCreateBridge         = "create_bridge"
+	OperationCreateBond           = "create_bond"
+	OperationCreateVLAN           = "create_vlan"
+	OperationCreatePhysical       = "create_physical"
+	OperationDisconnect           = "disconnect"
+	OperationAddTag               = "add_tag"
+	OperationRemoveTag            = "remove_tag"
+	OperationSetDefaultGW         = "set_default_gateway"
+	OperationSetDefaultDomain     = "set_default"
+	OperationRebuild              = "rebuild"
+	OperationUpdateNodes          = "update_nodes"
+	OperationTagMachines          = "machines"
+	OperationTagDevices           = "devices"
+	OperationTagRackControllers   = "rack_controllers"
+	OperationTagRegionControllers = "region_controllers"
+	OperationReleaseIPAddress     = "release"
+	OperationReserveIPAddress     = "reserve"
+	OperationStatistics           = "statistics"
+	OperationDetails              = "details"
+	OperationImportSSHKeys        = "import"
+	OperationUnreservedIPRanges   = "unreserved_ip_ranges"
+
+	OperationCreateLogicalVolume = "create_logical_volume"
+	OperationDeleteLogicalVolume = "delete_logical_volume"
 )
@@ -20,8 +20,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -33,6 +36,13 @@ type DNSResources interface {
 	List(ctx context.Context, params Params) ([]DNSResource, error)
 	Builder() DNSResourceBuilder
 	DNSResource(id int) DNSResource
+	// Upsert makes the record for fqdn resolve to exactly addresses, creating it if needed.
+	// At least one address is required.
+	// A ttl of 0 leaves the TTL unmanaged, a shorter TTL than a second is an error. It reports
+	// whether anything was changed.
+	Upsert(ctx context.Context, fqdn string, addresses []string, ttl time.Duration) (DNSResource, bool, error)
+	// EnsureAbsent deletes the record for fqdn if there is one. It reports whether anything was deleted.
+	EnsureAbsent(ctx context.Context, fqdn string) (bool, error)
 }
 
 type DNSResource interface {
@@ -168,7 +178,7 @@ func (d *dnsResource) UnmarshalJSON(data []byte) error {
 }
 
 type dnsResources struct {
-	client  *authenticatedClient
+	client  Client
 	apiPath string
 	params  Params
 }
@@ -206,7 +216,11 @@ func (r *dnsResources) WithIPAddresses(ipAddresses []string) DNSResourceBuilder
 }
 
 func (r *dnsResources) Create(ctx context.Context) (DNSResource, error) {
-	data, err := r.client.Post(ctx, r.apiPath, r.params.Values())
+	return r.create(ctx, r.params)
+}
+
+func (r *dnsResources) create(ctx context.Context, params Params) (DNSResource, error) {
+	data, err := r.client.Post(ctx, r.apiPath, params.Values())
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +254,127 @@ func (r *dnsResources) List(ctx context.Context, params Params) ([]DNSResource,
 	return dnsResourceSliceToInterfaceSlice(obj, r.client), nil
 }
 
+func (r *dnsResources) Upsert(ctx context.Context, fqdn string, addresses []string, ttl time.Duration) (DNSResource, bool, error) {
+	wanted, err := normalizeAddresses(addresses)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(wanted) == 0 {
+		return nil, false, fmt.Errorf("no addresses given for %s, use EnsureAbsent to remove the record", fqdn)
+	}
+	if ttl > 0 && ttl < time.Second {
+		return nil, false, fmt.Errorf("ttl %s for %s is below the one second resolution of MAAS", ttl, fqdn)
+	}
+	seconds := int(ttl.Seconds())
+
+	existing, err := r.lookup(ctx, fqdn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		// Built locally so the create doesn't share the params of the Builder
+		params := ParamsBuilder().Set(FQDNKey, fqdn).Set(IPAddressesKey, strings.Join(wanted, " "))
+		if seconds > 0 {
+			params.Set(AddressTTLKey, strconv.Itoa(seconds))
+		}
+
+		created, createErr := r.create(ctx, params)
+		if createErr == nil {
+			return created, true, nil
+		}
+
+		// Someone else may have created the record since the lookup, converge theirs instead
+		existing, err = r.lookup(ctx, fqdn)
+		if err != nil || existing == nil {
+			return nil, false, createErr
+		}
+	}
+
+	var current []string
+	for _, ip := range existing.ipAddresses {
+		current = append(current, ip.ip.String())
+	}
+	current, _ = normalizeAddresses(current)
+
+	sameTTL := seconds <= 0 || (existing.addressTTL != nil && *existing.addressTTL == seconds)
+	if sameTTL && strings.Join(current, " ") == strings.Join(wanted, " ") {
+		return existing, false, nil
+	}
+
+	modifier := existing.Modifier().SetIPAddresses(wanted)
+	if seconds > 0 {
+		modifier.SetAddressTTL(seconds)
+	}
+
+	updated, err := modifier.Modify(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return updated, true, nil
+}
+
+func (r *dnsResources) EnsureAbsent(ctx context.Context, fqdn string) (bool, error) {
+	existing, err := r.lookup(ctx, fqdn)
+	if err != nil || existing == nil {
+		return false, err
+	}
+
+	err = existing.Delete(ctx)
+	if isNotFound(err) {
+		// Deleted concurrently
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// lookup returns the record for fqdn, or nil if there is none
+func (r *dnsResources) lookup(ctx context.Context, fqdn string) (*dnsResource, error) {
+	data, err := r.client.Get(ctx, r.apiPath, ParamsBuilder().Set(FQDNKey, fqdn).Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*dnsResource
+	err = unMarshalJson(data, &obj)
+	if isNotFound(err) {
+		// MAAS answers 404 when the domain of fqdn doesn't exist
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range obj {
+		if strings.EqualFold(d.fqdn, fqdn) {
+			dnsResourceStructToInterface(d, r.client)
+			return d, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// normalizeAddresses validates addresses and returns them in canonical form, sorted and de-duplicated
+func normalizeAddresses(addresses []string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", address)
+		}
+		if !seen[ip.String()] {
+			seen[ip.String()] = true
+			out = append(out, ip.String())
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
 func dnsResourceSliceToInterfaceSlice(d []*dnsResource, client Client) []DNSResource {
 	var result []DNSResource
 	for _, dr := range d {
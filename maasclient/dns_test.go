@@ -18,10 +18,13 @@ package maasclient
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetDNSResources(t *testing.T) {
@@ -119,3 +122,153 @@ func TestGetDNSResources(t *testing.T) {
 	//assert.NotEmpty(t, res.Faces[0].FaceToken, "expecting non-empty face_token")
 	//assert.Greater(t, len(res.Faces[0].FaceImages), 0, "expecting non-empty face_images")
 }
+
+// newUpsertStubClient simulates a record created concurrently between lookup and create,
+// answering lookups with records in turn
+func newUpsertStubClient(records ...string) *stubClient {
+	return newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			if len(records) == 0 {
+				return stubResponse(http.StatusOK, `[]`)
+			}
+			body := records[0]
+			records = records[1:]
+			return stubResponse(http.StatusOK, body)
+		},
+		http.MethodPost: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusBadRequest, `{"__all__": ["DNS resource with this name already exists."]}`)
+		},
+		http.MethodPut: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 9, "fqdn": "vip.maas", "address_ttl": 30, "ip_addresses": [{"ip": "10.0.0.5"}]}`)
+		},
+		http.MethodDelete: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusNotFound, `Not Found`)
+		},
+	})
+}
+
+func TestDNSResources_Upsert(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(stub *stubClient) DNSResources {
+		return &dnsResources{client: stub, params: ParamsBuilder(), apiPath: DNSResourcesAPIPath}
+	}
+
+	t.Run("concurrently created record is converged", func(t *testing.T) {
+		stub := newUpsertStubClient(
+			`[]`,
+			`[{"id": 9, "fqdn": "vip.maas", "address_ttl": null, "ip_addresses": [{"ip": "10.0.0.4"}]}]`,
+		)
+		res, changed, err := newClient(stub).Upsert(ctx, "vip.maas", []string{"10.0.0.5"}, 30*time.Second)
+		assert.Nil(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, res.ID(), 9)
+		puts := stub.sent(http.MethodPut)
+		assert.Len(t, puts, 1)
+		assert.Equal(t, puts[0].params.Get(IPAddressesKey), "10.0.0.5")
+		assert.Equal(t, puts[0].params.Get(AddressTTLKey), "30")
+	})
+
+	t.Run("matching record is left alone", func(t *testing.T) {
+		stub := newUpsertStubClient(
+			`[{"id": 9, "fqdn": "vip.maas", "address_ttl": 30, "ip_addresses": [{"ip": "10.0.0.6"}, {"ip": "10.0.0.5"}]}]`,
+		)
+		_, changed, err := newClient(stub).Upsert(ctx, "vip.maas", []string{"10.0.0.5", "10.0.0.6", "10.0.0.5"}, 30*time.Second)
+		assert.Nil(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, stub.sent(http.MethodPut))
+	})
+
+	t.Run("create failure without record is returned", func(t *testing.T) {
+		stub := newUpsertStubClient()
+		_, changed, err := newClient(stub).Upsert(ctx, "vip.maas", []string{"10.0.0.5"}, 0)
+		assert.NotNil(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("creates don't share the builder params", func(t *testing.T) {
+		stub := newUpsertStubClient()
+		client := newClient(stub)
+		client.Builder().WithName("other")
+		for _, fqdn := range []string{"vip.maas", "vip2.maas"} {
+			_, _, err := client.Upsert(ctx, fqdn, []string{"10.0.0.5"}, 30*time.Second)
+			assert.NotNil(t, err)
+		}
+		posts := stub.sent(http.MethodPost)
+		if assert.Len(t, posts, 2) {
+			assert.Equal(t, posts[1].path, DNSResourcesAPIPath)
+			assert.Equal(t, posts[1].params[FQDNKey], []string{"vip2.maas"})
+			assert.Equal(t, posts[1].params[IPAddressesKey], []string{"10.0.0.5"})
+			assert.Equal(t, posts[1].params[AddressTTLKey], []string{"30"})
+			assert.Empty(t, posts[1].params.Get(NameKey))
+		}
+	})
+
+	t.Run("sub-second ttl is rejected", func(t *testing.T) {
+		stub := newUpsertStubClient()
+		_, changed, err := newClient(stub).Upsert(ctx, "vip.maas", []string{"10.0.0.5"}, 500*time.Millisecond)
+		assert.NotNil(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, stub.requests)
+	})
+
+	t.Run("empty addresses are rejected", func(t *testing.T) {
+		stub := newUpsertStubClient()
+		_, changed, err := newClient(stub).Upsert(ctx, "vip.maas", nil, 0)
+		assert.NotNil(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, stub.requests)
+	})
+
+	t.Run("absent record", func(t *testing.T) {
+		changed, err := newClient(newUpsertStubClient()).EnsureAbsent(ctx, "vip.maas")
+		assert.Nil(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("record deleted concurrently", func(t *testing.T) {
+		stub := newUpsertStubClient(`[{"id": 9, "fqdn": "vip.maas", "ip_addresses": []}]`)
+		changed, err := newClient(stub).EnsureAbsent(ctx, "vip.maas")
+		assert.Nil(t, err)
+		assert.False(t, changed)
+		assert.Len(t, stub.sent(http.MethodDelete), 1)
+	})
+}
+
+func TestDNSResources_UpsertLive(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with an unused name in a domain MAAS is authoritative for
+	fqdn := "REPLACE_WITH_TEST_FQDN"
+
+	t.Run("upsert and ensure absent", func(t *testing.T) {
+		if fqdn == "REPLACE_WITH_TEST_FQDN" {
+			t.Skip("Please replace placeholder FQDN")
+			return
+		}
+
+		res, changed, err := c.DNSResources().Upsert(ctx, fqdn, []string{"1.2.3.4"}, 10*time.Second)
+		require.Nil(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, res.AddressTTL(), 10)
+
+		_, changed, err = c.DNSResources().Upsert(ctx, fqdn, []string{"1.2.3.4"}, 10*time.Second)
+		assert.Nil(t, err)
+		assert.False(t, changed)
+
+		res, changed, err = c.DNSResources().Upsert(ctx, fqdn, []string{"1.2.3.4", "5.6.7.8"}, 10*time.Second)
+		require.Nil(t, err)
+		assert.True(t, changed)
+		assert.Len(t, res.IPAddresses(), 2)
+
+		changed, err = c.DNSResources().EnsureAbsent(ctx, fqdn)
+		assert.Nil(t, err)
+		assert.True(t, changed)
+
+		changed, err = c.DNSResources().EnsureAbsent(ctx, fqdn)
+		assert.Nil(t, err)
+		assert.False(t, changed)
+	})
+}
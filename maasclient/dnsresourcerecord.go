@@ -0,0 +1,388 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DNSResourceRecordsAPIPath  = "/dnsresourcerecords/"
+	DNSResourceRecordAPIFormat = "/dnsresourcerecords/%d/"
+)
+
+// DNSResourceRecords manages non-address DNS records. Address records are managed through DNSResources.
+type DNSResourceRecords interface {
+	// List returns the records matching params, which may filter on FQDNKey, DomainKey, NameKey and RRTypeKey
+	List(ctx context.Context, params Params) ([]DNSResourceRecord, error)
+	DNSResourceRecord(id int) DNSResourceRecord
+	Builder() DNSResourceRecordBuilder
+}
+
+type DNSResourceRecord interface {
+	Get(ctx context.Context) (DNSResourceRecord, error)
+	Delete(ctx context.Context) error
+	Modifier() DNSResourceRecordModifier
+	ID() int
+	FQDN() string
+	// TTL returns the TTL of the record, 0 when the domain default applies
+	TTL() time.Duration
+	// RRType returns one of the RRType* constants
+	RRType() string
+	// RRData returns the record data in zone file presentation format
+	RRData() string
+}
+
+// DNSResourceRecordBuilder creates a record. The name is set with WithFQDN, or WithName and
+// WithDomain, and the type and data with exactly one of the typed setters.
+type DNSResourceRecordBuilder interface {
+	WithFQDN(fqdn string) DNSResourceRecordBuilder
+	WithName(name string) DNSResourceRecordBuilder
+	WithDomain(domain string) DNSResourceRecordBuilder
+	WithTTL(ttl time.Duration) DNSResourceRecordBuilder
+	WithCNAME(target string) DNSResourceRecordBuilder
+	WithTXT(text string) DNSResourceRecordBuilder
+	WithMX(preference int, exchange string) DNSResourceRecordBuilder
+	WithSRV(priority, weight, port int, target string) DNSResourceRecordBuilder
+	WithNS(nameserver string) DNSResourceRecordBuilder
+	// WithSSHFP sets an SSH fingerprint, see the SSHFPAlgorithm* and SSHFPType* constants
+	WithSSHFP(algorithm, fingerprintType int, fingerprint string) DNSResourceRecordBuilder
+	Create(ctx context.Context) (DNSResourceRecord, error)
+}
+
+type DNSResourceRecordModifier interface {
+	SetTTL(ttl time.Duration) DNSResourceRecordModifier
+	SetCNAME(target string) DNSResourceRecordModifier
+	SetTXT(text string) DNSResourceRecordModifier
+	SetMX(preference int, exchange string) DNSResourceRecordModifier
+	SetSRV(priority, weight, port int, target string) DNSResourceRecordModifier
+	SetNS(nameserver string) DNSResourceRecordModifier
+	SetSSHFP(algorithm, fingerprintType int, fingerprint string) DNSResourceRecordModifier
+	Update(ctx context.Context) (DNSResourceRecord, error)
+}
+
+type dnsResourceRecords struct {
+	Controller
+}
+
+func (rs *dnsResourceRecords) List(ctx context.Context, params Params) ([]DNSResourceRecord, error) {
+	var values url.Values
+	if params != nil {
+		values = params.Values()
+	}
+
+	res, err := rs.client.Get(ctx, rs.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*dnsResourceRecord
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return dnsResourceRecordStructSliceToInterface(obj, rs.client), nil
+}
+
+func (rs *dnsResourceRecords) DNSResourceRecord(id int) DNSResourceRecord {
+	return dnsResourceRecordStructToInterface(&dnsResourceRecord{id: id}, rs.client)
+}
+
+func (rs *dnsResourceRecords) Builder() DNSResourceRecordBuilder {
+	rs.params.Reset()
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithFQDN(fqdn string) DNSResourceRecordBuilder {
+	rs.params.Set(FQDNKey, fqdn)
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithName(name string) DNSResourceRecordBuilder {
+	rs.params.Set(NameKey, name)
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithDomain(domain string) DNSResourceRecordBuilder {
+	rs.params.Set(DomainKey, domain)
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithTTL(ttl time.Duration) DNSResourceRecordBuilder {
+	rs.params.Set(TTLKey, strconv.Itoa(int(ttl.Seconds())))
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithCNAME(target string) DNSResourceRecordBuilder {
+	setRRData(rs.params, RRTypeCNAME, target)
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithTXT(text string) DNSResourceRecordBuilder {
+	setRRData(rs.params, RRTypeTXT, text)
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithMX(preference int, exchange string) DNSResourceRecordBuilder {
+	setRRData(rs.params, RRTypeMX, fmt.Sprintf("%d %s", preference, exchange))
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithSRV(priority, weight, port int, target string) DNSResourceRecordBuilder {
+	setRRData(rs.params, RRTypeSRV, fmt.Sprintf("%d %d %d %s", priority, weight, port, target))
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithNS(nameserver string) DNSResourceRecordBuilder {
+	setRRData(rs.params, RRTypeNS, nameserver)
+	return rs
+}
+
+func (rs *dnsResourceRecords) WithSSHFP(algorithm, fingerprintType int, fingerprint string) DNSResourceRecordBuilder {
+	setRRData(rs.params, RRTypeSSHFP, fmt.Sprintf("%d %d %s", algorithm, fingerprintType, fingerprint))
+	return rs
+}
+
+func (rs *dnsResourceRecords) Create(ctx context.Context) (DNSResourceRecord, error) {
+	values := rs.params.Values()
+	if values.Get(FQDNKey) == "" && values.Get(NameKey) == "" {
+		return nil, fmt.Errorf("DNS resource record requires an FQDN or a name")
+	}
+	if err := validateRRData(values.Get(RRTypeKey), values.Get(RRDataKey)); err != nil {
+		return nil, err
+	}
+
+	res, err := rs.client.Post(ctx, rs.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *dnsResourceRecord
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return dnsResourceRecordStructToInterface(obj, rs.client), nil
+}
+
+func setRRData(params Params, rrtype, rrdata string) {
+	params.Set(RRTypeKey, rrtype)
+	params.Set(RRDataKey, rrdata)
+}
+
+// validateRRData checks the record data built by the typed setters before MAAS is called
+func validateRRData(rrtype, rrdata string) error {
+	fields := strings.Fields(rrdata)
+
+	inRange := func(value string, max int) bool {
+		n, err := strconv.Atoi(value)
+		return err == nil && n >= 0 && n <= max
+	}
+
+	switch rrtype {
+	case "":
+		return fmt.Errorf("DNS resource record requires a record type")
+	case RRTypeCNAME, RRTypeNS:
+		if len(fields) != 1 {
+			return fmt.Errorf("invalid %s target %q", rrtype, rrdata)
+		}
+	case RRTypeTXT:
+		if rrdata == "" {
+			return fmt.Errorf("TXT record requires text")
+		}
+	case RRTypeMX:
+		if len(fields) != 2 || !inRange(fields[0], 65535) {
+			return fmt.Errorf("invalid MX data %q, the preference must be between 0 and 65535", rrdata)
+		}
+	case RRTypeSRV:
+		if len(fields) != 4 || !inRange(fields[0], 65535) || !inRange(fields[1], 65535) || !inRange(fields[2], 65535) {
+			return fmt.Errorf("invalid SRV data %q, the priority, weight and port must be between 0 and 65535", rrdata)
+		}
+	case RRTypeSSHFP:
+		if len(fields) != 3 || !inRange(fields[0], 255) || !inRange(fields[1], 255) {
+			return fmt.Errorf("invalid SSHFP data %q", rrdata)
+		}
+		if _, err := hex.DecodeString(fields[2]); err != nil {
+			return fmt.Errorf("invalid SSHFP fingerprint %q, it must be hex encoded", fields[2])
+		}
+	}
+
+	return nil
+}
+
+func dnsResourceRecordStructSliceToInterface(in []*dnsResourceRecord, client Client) []DNSResourceRecord {
+	var out []DNSResourceRecord
+	for _, r := range in {
+		out = append(out, dnsResourceRecordStructToInterface(r, client))
+	}
+	return out
+}
+
+func dnsResourceRecordStructToInterface(in *dnsResourceRecord, client Client) DNSResourceRecord {
+	in.client = client
+	in.apiPath = fmt.Sprintf(DNSResourceRecordAPIFormat, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type dnsResourceRecord struct {
+	Controller
+	id     int
+	fqdn   string
+	ttl    int
+	rrtype string
+	rrdata string
+}
+
+func (r *dnsResourceRecord) Get(ctx context.Context) (DNSResourceRecord, error) {
+	res, err := r.client.Get(ctx, r.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *dnsResourceRecord) Delete(ctx context.Context) error {
+	res, err := r.client.Delete(ctx, r.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (r *dnsResourceRecord) Modifier() DNSResourceRecordModifier {
+	r.params.Reset()
+	return r
+}
+
+func (r *dnsResourceRecord) SetTTL(ttl time.Duration) DNSResourceRecordModifier {
+	r.params.Set(TTLKey, strconv.Itoa(int(ttl.Seconds())))
+	return r
+}
+
+func (r *dnsResourceRecord) SetCNAME(target string) DNSResourceRecordModifier {
+	setRRData(r.params, RRTypeCNAME, target)
+	return r
+}
+
+func (r *dnsResourceRecord) SetTXT(text string) DNSResourceRecordModifier {
+	setRRData(r.params, RRTypeTXT, text)
+	return r
+}
+
+func (r *dnsResourceRecord) SetMX(preference int, exchange string) DNSResourceRecordModifier {
+	setRRData(r.params, RRTypeMX, fmt.Sprintf("%d %s", preference, exchange))
+	return r
+}
+
+func (r *dnsResourceRecord) SetSRV(priority, weight, port int, target string) DNSResourceRecordModifier {
+	setRRData(r.params, RRTypeSRV, fmt.Sprintf("%d %d %d %s", priority, weight, port, target))
+	return r
+}
+
+func (r *dnsResourceRecord) SetNS(nameserver string) DNSResourceRecordModifier {
+	setRRData(r.params, RRTypeNS, nameserver)
+	return r
+}
+
+func (r *dnsResourceRecord) SetSSHFP(algorithm, fingerprintType int, fingerprint string) DNSResourceRecordModifier {
+	setRRData(r.params, RRTypeSSHFP, fmt.Sprintf("%d %d %s", algorithm, fingerprintType, fingerprint))
+	return r
+}
+
+func (r *dnsResourceRecord) Update(ctx context.Context) (DNSResourceRecord, error) {
+	values := r.params.Values()
+	if values.Has(RRTypeKey) {
+		if err := validateRRData(values.Get(RRTypeKey), values.Get(RRDataKey)); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := r.client.PutParams(ctx, r.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *dnsResourceRecord) ID() int {
+	return r.id
+}
+
+func (r *dnsResourceRecord) FQDN() string {
+	return r.fqdn
+}
+
+func (r *dnsResourceRecord) TTL() time.Duration {
+	return time.Duration(r.ttl) * time.Second
+}
+
+func (r *dnsResourceRecord) RRType() string {
+	return r.rrtype
+}
+
+func (r *dnsResourceRecord) RRData() string {
+	return r.rrdata
+}
+
+func (r *dnsResourceRecord) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID     int    `json:"id"`
+		FQDN   string `json:"fqdn"`
+		TTL    *int   `json:"ttl"`
+		RRType string `json:"rrtype"`
+		RRData string `json:"rrdata"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	r.id = des.ID
+	r.fqdn = des.FQDN
+	r.ttl = 0
+	if des.TTL != nil {
+		r.ttl = *des.TTL
+	}
+	r.rrtype = strings.ToUpper(des.RRType)
+	r.rrdata = des.RRData
+
+	return nil
+}
+
+func NewDNSResourceRecordsClient(client Client) DNSResourceRecords {
+	return &dnsResourceRecords{
+		Controller: Controller{
+			client:  client,
+			apiPath: DNSResourceRecordsAPIPath,
+			params:  ParamsBuilder(),
+		},
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSResourceRecord_Unmarshal(t *testing.T) {
+	data := `{"id": 7, "fqdn": "_etcd._tcp.cluster.maas", "ttl": null, "rrtype": "srv", "rrdata": "0 5 2379 etcd-0.cluster.maas"}`
+
+	var r *dnsResourceRecord
+	err := json.Unmarshal([]byte(data), &r)
+	assert.Nil(t, err)
+	assert.Equal(t, r.FQDN(), "_etcd._tcp.cluster.maas")
+	assert.Zero(t, r.TTL())
+	assert.Equal(t, r.RRType(), RRTypeSRV)
+	assert.Equal(t, r.RRData(), "0 5 2379 etcd-0.cluster.maas")
+
+	err = json.Unmarshal([]byte(`{"id": 7, "ttl": 300}`), &r)
+	assert.Nil(t, err)
+	assert.Equal(t, r.TTL(), 5*time.Minute)
+}
+
+func TestDNSResourceRecords_BuilderValidation(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		builder DNSResourceRecordBuilder
+	}{
+		{"missing name", c.DNSResourceRecords().Builder().WithCNAME("target.maas")},
+		{"missing type", c.DNSResourceRecords().Builder().WithFQDN("www.maas")},
+		{"cname with spaces", c.DNSResourceRecords().Builder().WithFQDN("www.maas").WithCNAME("a b")},
+		{"empty txt", c.DNSResourceRecords().Builder().WithFQDN("www.maas").WithTXT("")},
+		{"mx preference out of range", c.DNSResourceRecords().Builder().WithFQDN("maas").WithMX(70000, "mail.maas")},
+		{"srv negative port", c.DNSResourceRecords().Builder().WithFQDN("_etcd._tcp.maas").WithSRV(0, 5, -1, "etcd.maas")},
+		{"sshfp fingerprint not hex", c.DNSResourceRecords().Builder().WithFQDN("host.maas").WithSSHFP(SSHFPAlgorithmEd25519, SSHFPTypeSHA256, "xyz")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.builder.Create(ctx)
+			assert.NotNil(t, err)
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func TestDNSResourceRecords(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	t.Run("create, update and delete srv record", func(t *testing.T) {
+		res, err := c.DNSResourceRecords().Builder().
+			WithName("_etcd-server._tcp").
+			WithDomain("maas").
+			WithSRV(0, 5, 2380, "etcd-0.maas").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.RRType(), RRTypeSRV)
+
+		res, err = res.Modifier().SetSRV(10, 5, 2380, "etcd-1.maas").SetTTL(time.Minute).Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.RRData(), "10 5 2380 etcd-1.maas")
+		assert.Equal(t, res.TTL(), time.Minute)
+
+		list, err := c.DNSResourceRecords().List(ctx, ParamsBuilder().Set(RRTypeKey, RRTypeSRV).Set(DomainKey, "maas"))
+		assert.Nil(t, err)
+		assert.NotEmpty(t, list)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+
+	t.Run("create txt and mx records", func(t *testing.T) {
+		txt, err := c.DNSResourceRecords().Builder().WithFQDN("txt-test.maas").WithTXT("v=spf1 -all").Create(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, txt.RRData(), "v=spf1 -all")
+
+		mx, err := c.DNSResourceRecords().Builder().WithFQDN("txt-test.maas").WithMX(10, "mail.maas").Create(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, mx.RRType(), RRTypeMX)
+
+		assert.Nil(t, txt.Delete(ctx))
+		assert.Nil(t, mx.Delete(ctx))
+	})
+}
@@ -20,26 +20,56 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	DomainsAPIPath      = "/domains/"
-	DomainAPIPathFormat = "/domains/%d"
+	DomainAPIPathFormat = "/domains/%d/"
 )
 
 type Domains interface {
 	List(ctx context.Context) ([]Domain, error)
 	Domain(id int) Domain
+	Builder() DomainBuilder
 }
 
 type Domain interface {
+	Get(ctx context.Context) (Domain, error)
+	Delete(ctx context.Context) error
+	Modifier() DomainModifier
+	// SetDefault makes the domain the default for newly deployed machines
+	SetDefault(ctx context.Context) (Domain, error)
+	// DNSResources returns the DNS resources defined in the domain
+	DNSResources(ctx context.Context) ([]DNSResource, error)
 	ID() int
 	IsAuthoritative() bool
+	// TTL returns the default TTL of records in the domain, 0 when the global default applies
 	TTL() time.Duration
 	IsDefault() bool
 	Name() string
 	ResourceRecordCount() int
+	// ForwardDNSServers returns the servers queries for a non-authoritative domain are forwarded to
+	ForwardDNSServers() []string
+}
+
+type DomainBuilder interface {
+	WithName(name string) DomainBuilder
+	WithAuthoritative(authoritative bool) DomainBuilder
+	WithTTL(ttl time.Duration) DomainBuilder
+	WithForwardDNSServers(servers []string) DomainBuilder
+	Create(ctx context.Context) (Domain, error)
+}
+
+type DomainModifier interface {
+	SetName(name string) DomainModifier
+	SetAuthoritative(authoritative bool) DomainModifier
+	SetTTL(ttl time.Duration) DomainModifier
+	SetForwardDNSServers(servers []string) DomainModifier
+	Update(ctx context.Context) (Domain, error)
 }
 
 type domains struct {
@@ -53,7 +83,7 @@ func (ds *domains) Domain(id int) Domain {
 }
 
 func (ds *domains) List(ctx context.Context) ([]Domain, error) {
-	res, err := ds.client.Get(ctx, ds.apiPath, ds.params.Values())
+	res, err := ds.client.Get(ctx, ds.apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +97,46 @@ func (ds *domains) List(ctx context.Context) ([]Domain, error) {
 	return domainStructSliceToInterface(obj, ds.client), nil
 }
 
+func (ds *domains) Builder() DomainBuilder {
+	ds.params.Reset()
+	return ds
+}
+
+func (ds *domains) WithName(name string) DomainBuilder {
+	ds.params.Set(NameKey, name)
+	return ds
+}
+
+func (ds *domains) WithAuthoritative(authoritative bool) DomainBuilder {
+	ds.params.Set(AuthoritativeKey, strconv.FormatBool(authoritative))
+	return ds
+}
+
+func (ds *domains) WithTTL(ttl time.Duration) DomainBuilder {
+	ds.params.Set(TTLKey, strconv.Itoa(int(ttl.Seconds())))
+	return ds
+}
+
+func (ds *domains) WithForwardDNSServers(servers []string) DomainBuilder {
+	ds.params.Set(ForwardDNSKey, strings.Join(servers, " "))
+	return ds
+}
+
+func (ds *domains) Create(ctx context.Context) (Domain, error) {
+	res, err := ds.client.Post(ctx, ds.apiPath, ds.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *domain
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return domainStructToInterface(obj, ds.client), nil
+}
+
 func domainStructSliceToInterface(in []*domain, client Client) []Domain {
 	var out []Domain
 	for _, d := range in {
@@ -89,9 +159,98 @@ type domain struct {
 	isDefault           bool
 	name                string
 	resourceRecordCount int
+	forwardDNSServers   []string
 	Controller
 }
 
+func (d *domain) Get(ctx context.Context) (Domain, error) {
+	res, err := d.client.Get(ctx, d.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, unMarshalJson(res, &d)
+}
+
+func (d *domain) Delete(ctx context.Context) error {
+	res, err := d.client.Delete(ctx, d.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (d *domain) Modifier() DomainModifier {
+	d.params.Reset()
+	return d
+}
+
+func (d *domain) SetName(name string) DomainModifier {
+	d.params.Set(NameKey, name)
+	return d
+}
+
+func (d *domain) SetAuthoritative(authoritative bool) DomainModifier {
+	d.params.Set(AuthoritativeKey, strconv.FormatBool(authoritative))
+	return d
+}
+
+func (d *domain) SetTTL(ttl time.Duration) DomainModifier {
+	d.params.Set(TTLKey, strconv.Itoa(int(ttl.Seconds())))
+	return d
+}
+
+func (d *domain) SetForwardDNSServers(servers []string) DomainModifier {
+	d.params.Set(ForwardDNSKey, strings.Join(servers, " "))
+	return d
+}
+
+func (d *domain) Update(ctx context.Context) (Domain, error) {
+	res, err := d.client.PutParams(ctx, d.apiPath, d.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return d, unMarshalJson(res, &d)
+}
+
+func (d *domain) SetDefault(ctx context.Context) (Domain, error) {
+	params := url.Values{}
+	params.Set(Operation, OperationSetDefaultDomain)
+
+	res, err := d.client.Post(ctx, d.apiPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, unMarshalJson(res, &d)
+}
+
+func (d *domain) DNSResources(ctx context.Context) ([]DNSResource, error) {
+	if d.name == "" {
+		if _, err := d.Get(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	params := url.Values{}
+	params.Set(DomainKey, d.name)
+
+	res, err := d.client.Get(ctx, DNSResourcesAPIPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*dnsResource
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return dnsResourceSliceToInterfaceSlice(obj, d.client), nil
+}
+
 func (d *domain) ID() int {
 	return d.id
 }
@@ -116,14 +275,19 @@ func (d *domain) ResourceRecordCount() int {
 	return d.resourceRecordCount
 }
 
+func (d *domain) ForwardDNSServers() []string {
+	return d.forwardDNSServers
+}
+
 func (d *domain) UnmarshalJSON(data []byte) error {
 	des := &struct {
-		Authoritative       bool   `json:"authoritative"`
-		TTL                 int    `json:"ttl"`
-		ResourceRecordCount int    `json:"resource_record_count"`
-		Name                string `json:"name"`
-		Id                  int    `json:"id"`
-		IsDefault           bool   `json:"is_default"`
+		Authoritative       bool     `json:"authoritative"`
+		TTL                 *int     `json:"ttl"`
+		ResourceRecordCount int      `json:"resource_record_count"`
+		Name                string   `json:"name"`
+		Id                  int      `json:"id"`
+		IsDefault           bool     `json:"is_default"`
+		ForwardDNSServers   []string `json:"forward_dns_servers"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -133,10 +297,14 @@ func (d *domain) UnmarshalJSON(data []byte) error {
 
 	d.id = des.Id
 	d.name = des.Name
-	d.ttl = des.TTL
+	d.ttl = 0
+	if des.TTL != nil {
+		d.ttl = *des.TTL
+	}
 	d.isAuthoritative = des.Authoritative
 	d.isDefault = des.IsDefault
 	d.resourceRecordCount = des.ResourceRecordCount
+	d.forwardDNSServers = des.ForwardDNSServers
 
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDomain(t *testing.T) {
@@ -33,4 +34,47 @@ func TestDomain(t *testing.T) {
 		assert.Nil(t, err)
 		assert.NotNil(t, res)
 	})
+
+	t.Run("create, update and delete domain", func(t *testing.T) {
+		res, err := c.Domains().Builder().
+			WithName("tenant-test.example").
+			WithAuthoritative(false).
+			WithTTL(5 * time.Minute).
+			WithForwardDNSServers([]string{"10.0.0.53"}).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.False(t, res.IsAuthoritative())
+		assert.Equal(t, res.TTL(), 5*time.Minute)
+		assert.Equal(t, res.ForwardDNSServers(), []string{"10.0.0.53"})
+
+		res, err = res.Modifier().SetAuthoritative(true).SetTTL(time.Minute).Update(ctx)
+		assert.Nil(t, err)
+		assert.True(t, res.IsAuthoritative())
+		assert.Equal(t, res.TTL(), time.Minute)
+
+		res, err = c.Domains().Domain(res.ID()).Get(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Name(), "tenant-test.example")
+
+		records, err := res.DNSResources(ctx)
+		assert.Nil(t, err)
+		assert.Empty(t, records)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+
+	t.Run("set default domain", func(t *testing.T) {
+		list, err := c.Domains().List(ctx)
+		assert.Nil(t, err)
+
+		for _, d := range list {
+			if d.IsDefault() {
+				res, err := d.SetDefault(ctx)
+				assert.Nil(t, err)
+				assert.True(t, res.IsDefault())
+			}
+		}
+	})
 }
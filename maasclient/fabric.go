@@ -0,0 +1,245 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	FabricsAPIPath      = "/fabrics/"
+	FabricAPIPathFormat = "/fabrics/%d/"
+)
+
+type Fabrics interface {
+	List(ctx context.Context) ([]Fabric, error)
+	Fabric(id int) Fabric
+	Builder() FabricBuilder
+}
+
+type Fabric interface {
+	Get(ctx context.Context) (Fabric, error)
+	Delete(ctx context.Context) error
+	Modifier() FabricModifier
+	ID() int
+	Name() string
+	Description() string
+	ClassType() string
+	// VLANs returns the VLANs on the fabric, including the default untagged VLAN
+	VLANs() []VLAN
+}
+
+type FabricBuilder interface {
+	WithName(name string) FabricBuilder
+	WithDescription(description string) FabricBuilder
+	WithClassType(classType string) FabricBuilder
+	Create(ctx context.Context) (Fabric, error)
+}
+
+type FabricModifier interface {
+	SetName(name string) FabricModifier
+	SetDescription(description string) FabricModifier
+	SetClassType(classType string) FabricModifier
+	Update(ctx context.Context) (Fabric, error)
+}
+
+type fabrics struct {
+	Controller
+}
+
+func (fs *fabrics) List(ctx context.Context) ([]Fabric, error) {
+	res, err := fs.client.Get(ctx, fs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*fabric
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return fabricStructSliceToInterface(obj, fs.client), nil
+}
+
+func (fs *fabrics) Fabric(id int) Fabric {
+	return fabricStructToInterface(&fabric{id: id}, fs.client)
+}
+
+func (fs *fabrics) Builder() FabricBuilder {
+	fs.params.Reset()
+	return fs
+}
+
+func (fs *fabrics) WithName(name string) FabricBuilder {
+	fs.params.Set(NameKey, name)
+	return fs
+}
+
+func (fs *fabrics) WithDescription(description string) FabricBuilder {
+	fs.params.Set(DescriptionKey, description)
+	return fs
+}
+
+func (fs *fabrics) WithClassType(classType string) FabricBuilder {
+	fs.params.Set(ClassTypeKey, classType)
+	return fs
+}
+
+func (fs *fabrics) Create(ctx context.Context) (Fabric, error) {
+	res, err := fs.client.Post(ctx, fs.apiPath, fs.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *fabric
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return fabricStructToInterface(obj, fs.client), nil
+}
+
+func fabricStructSliceToInterface(in []*fabric, client Client) []Fabric {
+	var out []Fabric
+	for _, f := range in {
+		out = append(out, fabricStructToInterface(f, client))
+	}
+	return out
+}
+
+func fabricStructToInterface(in *fabric, client Client) Fabric {
+	in.client = client
+	in.apiPath = fmt.Sprintf(FabricAPIPathFormat, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type fabric struct {
+	Controller
+	id          int
+	name        string
+	description string
+	classType   string
+	vlans       []*vlan
+}
+
+func (f *fabric) Get(ctx context.Context) (Fabric, error) {
+	res, err := f.client.Get(ctx, f.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, unMarshalJson(res, &f)
+}
+
+func (f *fabric) Delete(ctx context.Context) error {
+	res, err := f.client.Delete(ctx, f.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (f *fabric) Modifier() FabricModifier {
+	f.params.Reset()
+	return f
+}
+
+func (f *fabric) SetName(name string) FabricModifier {
+	f.params.Set(NameKey, name)
+	return f
+}
+
+func (f *fabric) SetDescription(description string) FabricModifier {
+	f.params.Set(DescriptionKey, description)
+	return f
+}
+
+func (f *fabric) SetClassType(classType string) FabricModifier {
+	f.params.Set(ClassTypeKey, classType)
+	return f
+}
+
+func (f *fabric) Update(ctx context.Context) (Fabric, error) {
+	res, err := f.client.PutParams(ctx, f.apiPath, f.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return f, unMarshalJson(res, &f)
+}
+
+func (f *fabric) ID() int {
+	return f.id
+}
+
+func (f *fabric) Name() string {
+	return f.name
+}
+
+func (f *fabric) Description() string {
+	return f.description
+}
+
+func (f *fabric) ClassType() string {
+	return f.classType
+}
+
+func (f *fabric) VLANs() []VLAN {
+	return vlanStructSliceToInterface(f.vlans, f.client)
+}
+
+func (f *fabric) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID          int     `json:"id"`
+		Name        string  `json:"name"`
+		Description string  `json:"description"`
+		ClassType   *string `json:"class_type"`
+		VLANs       []*vlan `json:"vlans"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	f.id = des.ID
+	f.name = des.Name
+	f.description = des.Description
+	f.classType = ""
+	if des.ClassType != nil {
+		f.classType = *des.ClassType
+	}
+	f.vlans = des.VLANs
+
+	return nil
+}
+
+func NewFabricsClient(client Client) Fabrics {
+	return &fabrics{
+		Controller: Controller{
+			client:  client,
+			apiPath: FabricsAPIPath,
+			params:  ParamsBuilder(),
+		},
+	}
+}
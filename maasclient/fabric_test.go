@@ -0,0 +1,60 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabrics(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	t.Run("list fabrics", func(t *testing.T) {
+		res, err := c.Fabrics().List(ctx)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, res)
+		assert.NotEmpty(t, res[0].VLANs())
+	})
+
+	t.Run("create, update and delete fabric", func(t *testing.T) {
+		res, err := c.Fabrics().Builder().
+			WithName("fabric-test").
+			WithDescription("created by tests").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Name(), "fabric-test")
+		assert.Len(t, res.VLANs(), 1)
+
+		res, err = res.Modifier().SetClassType("10g").Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.ClassType(), "10g")
+
+		res, err = c.Fabrics().Fabric(res.ID()).Get(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Description(), "created by tests")
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
@@ -19,18 +19,30 @@ package maasclient
 import (
 	"encoding/json"
 	"net"
+	"time"
 )
 
 type IPAddress interface {
 	IP() net.IP
 	InterfaceSet() []NetworkInterface
+	// AllocType returns one of the IPAllocType* constants
+	AllocType() int
+	// AllocTypeName returns the human readable allocation type, e.g. "User reserved"
+	AllocTypeName() string
+	// Owner returns the username of the user the address is allocated to, if any
+	Owner() string
+	Created() time.Time
 }
 
 type ipaddress struct {
 	Controller
-	ip           net.IP
-	ipString     string
-	interfaceSet []NetworkInterface
+	ip            net.IP
+	ipString      string
+	interfaceSet  []NetworkInterface
+	allocType     int
+	allocTypeName string
+	owner         string
+	created       time.Time
 }
 
 func (i *ipaddress) IP() net.IP {
@@ -41,10 +53,30 @@ func (i *ipaddress) InterfaceSet() []NetworkInterface {
 	return i.interfaceSet
 }
 
+func (i *ipaddress) AllocType() int {
+	return i.allocType
+}
+
+func (i *ipaddress) AllocTypeName() string {
+	return i.allocTypeName
+}
+
+func (i *ipaddress) Owner() string {
+	return i.owner
+}
+
+func (i *ipaddress) Created() time.Time {
+	return i.created
+}
+
 func (i *ipaddress) UnmarshalJSON(data []byte) error {
 	aux := &struct {
-		IPString     string              `json:"ip"`
-		InterfaceSet []*networkInterface `json:"interface_set"`
+		IPString      string              `json:"ip"`
+		InterfaceSet  []*networkInterface `json:"interface_set"`
+		AllocType     int                 `json:"alloc_type"`
+		AllocTypeName string              `json:"alloc_type_name"`
+		Owner         json.RawMessage     `json:"owner"`
+		Created       string              `json:"created"`
 	}{}
 
 	err := json.Unmarshal(data, aux)
@@ -61,9 +93,33 @@ func (i *ipaddress) UnmarshalJSON(data []byte) error {
 		i.interfaceSet[idx] = netIf
 	}
 
+	i.allocType = aux.AllocType
+	i.allocTypeName = aux.AllocTypeName
+	i.owner, err = unmarshalUsername(aux.Owner)
+	if err != nil {
+		return err
+	}
+
+	i.created = time.Time{}
+	if aux.Created != "" {
+		i.created, err = parseTimestamp(aux.Created)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// parseTimestamp parses the timestamps MAAS returns, which have no time zone and are in UTC
+func parseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05.999999999", value, time.UTC)
+}
+
 func ipStructToInterface(in *ipaddress, client Client) IPAddress {
 	in.Controller = Controller{
 		client:  client,
@@ -17,8 +17,12 @@ limitations under the License.
 package maasclient
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"strings"
 )
 
 const (
@@ -33,6 +37,41 @@ type IPAddresses interface {
 	GetAll(ctx context.Context, ip string) (IPAddress, error)
 	Release(ctx context.Context, ip string) error
 	ForceRelease(ctx context.Context, ip string) error
+	// Reserve reserves an address for the current user. When a reservation of the user
+	// already matches the MAC or hostname of opts, that address is returned instead, or an
+	// error if it isn't on opts.Subnet.
+	Reserve(ctx context.Context, opts ReserveOptions) (IPAddress, error)
+}
+
+// ReserveOptions represents the parameters for reserving an IP address
+type ReserveOptions struct {
+	Subnet   string // Subnet CIDR or ID, required unless IP is given
+	IP       string // Address to reserve, the next free address of Subnet when empty
+	Hostname string // Creates a DNS record, in the default domain unless it is an FQDN or Domain is set
+	Domain   string
+	MAC      string // Ties the address to the MAC
+	Comment  string
+}
+
+func (o ReserveOptions) apply(params Params) {
+	if o.Subnet != "" {
+		params.Set(SubnetKey, o.Subnet)
+	}
+	if o.IP != "" {
+		params.Set(IPKey, o.IP)
+	}
+	if o.Hostname != "" {
+		params.Set(HostnameKey, o.Hostname)
+	}
+	if o.Domain != "" {
+		params.Set(DomainKey, o.Domain)
+	}
+	if o.MAC != "" {
+		params.Set(MACKey, o.MAC)
+	}
+	if o.Comment != "" {
+		params.Set(CommentKey, o.Comment)
+	}
 }
 
 // IPAddresses controller implementation
@@ -138,3 +177,156 @@ func (ips *ipAddresses) ForceRelease(ctx context.Context, ip string) error {
 	_, err := ips.client.Post(ctx, ips.apiPath, ips.params.Values())
 	return err
 }
+
+// Reserve reserves an address, returning the existing reservation for the same MAC or hostname if any
+func (ips *ipAddresses) Reserve(ctx context.Context, opts ReserveOptions) (IPAddress, error) {
+	if opts.Subnet == "" && opts.IP == "" {
+		return nil, fmt.Errorf("either a subnet or an IP address is required")
+	}
+
+	var ip net.IP
+	if opts.IP != "" {
+		if ip = net.ParseIP(opts.IP); ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", opts.IP)
+		}
+	}
+
+	existing, err := ips.findReservation(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if ip != nil && !existing.ip.Equal(ip) {
+			return nil, fmt.Errorf("cannot reserve %s, %s is already reserved for the same hostname or MAC", ip, existing.ip)
+		}
+		if opts.Subnet != "" {
+			cidr, err := ips.subnetCIDR(ctx, opts.Subnet)
+			if err != nil {
+				return nil, err
+			}
+			if !cidr.Contains(existing.ip) {
+				return nil, fmt.Errorf("cannot reserve on subnet %s, %s is already reserved outside it for the same hostname or MAC", opts.Subnet, existing.ip)
+			}
+		}
+		return ipStructToInterface(existing, ips.client), nil
+	}
+
+	ips.params.Reset()
+	ips.params.Set(Operation, OperationReserveIPAddress)
+	opts.apply(ips.params)
+
+	res, err := ips.client.Post(ctx, ips.apiPath, ips.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *ipaddress
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipStructToInterface(obj, ips.client), nil
+}
+
+// subnetCIDR returns the network of a subnet given by CIDR or by an ID or other MAAS specifier
+func (ips *ipAddresses) subnetCIDR(ctx context.Context, specifier string) (*net.IPNet, error) {
+	if _, cidr, err := net.ParseCIDR(specifier); err == nil {
+		return cidr, nil
+	}
+
+	res, err := ips.client.Get(ctx, SubnetsAPIPath+url.PathEscape(specifier)+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *subnet
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, cidr, err := net.ParseCIDR(obj.cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q of subnet %s: %w", obj.cidr, specifier, err)
+	}
+	return cidr, nil
+}
+
+// findReservation returns the address reserved by the current user bound to the MAC of opts
+// or, failing that, pointed to by the DNS record of its hostname. It returns nil if none is.
+func (ips *ipAddresses) findReservation(ctx context.Context, opts ReserveOptions) (*ipaddress, error) {
+	if opts.MAC == "" && opts.Hostname == "" {
+		return nil, nil
+	}
+
+	res, err := ips.client.Get(ctx, ips.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []*ipaddress
+	err = unMarshalJson(res, &addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Addresses the user holds through machines or devices aren't reservations
+	var owned []*ipaddress
+	for _, addr := range addresses {
+		if addr.allocType == IPAllocTypeUserReserved {
+			owned = append(owned, addr)
+		}
+	}
+
+	if opts.MAC != "" {
+		mac, err := net.ParseMAC(opts.MAC)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAC address %q: %w", opts.MAC, err)
+		}
+		for _, addr := range owned {
+			for _, iface := range addr.interfaceSet {
+				if ifaceMAC, err := net.ParseMAC(iface.MACAddress()); err == nil && bytes.Equal(ifaceMAC, mac) {
+					return addr, nil
+				}
+			}
+		}
+	}
+
+	if opts.Hostname == "" {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	if strings.Contains(opts.Hostname, ".") {
+		params.Set(FQDNKey, opts.Hostname)
+	} else {
+		params.Set(NameKey, opts.Hostname)
+		if opts.Domain != "" {
+			params.Set(DomainKey, opts.Domain)
+		}
+	}
+
+	res, err = ips.client.Get(ctx, DNSResourcesAPIPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*dnsResource
+	err = unMarshalJson(res, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		for _, recordIP := range record.ipAddresses {
+			for _, addr := range owned {
+				if addr.ip.Equal(recordIP.ip) {
+					return addr, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
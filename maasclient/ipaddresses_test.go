@@ -0,0 +1,170 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOwnedIPAddresses = `[
+	{"ip": "10.0.0.20", "alloc_type": 4, "alloc_type_name": "User reserved", "created": "2021-06-08T10:32:22.184",
+	 "owner": {"username": "admin"}, "interface_set": [{"id": 9, "mac_address": "52:54:00:aa:bb:cc"}]},
+	{"ip": "10.0.0.40", "alloc_type": 1, "alloc_type_name": "Sticky", "created": "2021-06-08T10:32:22",
+	 "owner": {"username": "admin"}, "interface_set": [{"id": 10, "mac_address": "52:54:00:dd:ee:ff"}]},
+	{"ip": "10.0.0.21", "alloc_type": 4, "alloc_type_name": "User reserved", "created": "2021-06-08T10:33:00",
+	 "owner": {"username": "admin"}, "interface_set": []}
+]`
+
+// newReserveStubClient serves ownedAddresses and a DNS record for "vip" pointing at 10.0.0.21
+func newReserveStubClient(ownedAddresses string) *stubClient {
+	return newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			if req.path == DNSResourcesAPIPath {
+				if req.params.Get(NameKey) == "vip" {
+					return stubResponse(http.StatusOK, `[{"id": 1, "fqdn": "vip.maas", "ip_addresses": [{"ip": "10.0.0.21"}]}]`)
+				}
+				return stubResponse(http.StatusOK, `[]`)
+			}
+			if req.path == SubnetsAPIPath+"7/" {
+				return stubResponse(http.StatusOK, `{"id": 7, "cidr": "10.0.0.0/24"}`)
+			}
+			return stubResponse(http.StatusOK, ownedAddresses)
+		},
+		http.MethodPost: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"ip": "10.0.0.30", "alloc_type": 4, "owner": {"username": "admin"}}`)
+		},
+	})
+}
+
+func TestIPAddress_Unmarshal(t *testing.T) {
+	var obj []*ipaddress
+	err := json.Unmarshal([]byte(testOwnedIPAddresses), &obj)
+	assert.Nil(t, err)
+	assert.Equal(t, obj[0].AllocType(), IPAllocTypeUserReserved)
+	assert.Equal(t, obj[0].AllocTypeName(), "User reserved")
+	assert.Equal(t, obj[0].Owner(), "admin")
+	assert.Equal(t, obj[0].Created(), time.Date(2021, 6, 8, 10, 32, 22, 184000000, time.UTC))
+	assert.Equal(t, obj[2].Created(), time.Date(2021, 6, 8, 10, 33, 0, 0, time.UTC))
+}
+
+func TestIPAddresses_ReserveIdempotent(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(stub *stubClient) IPAddresses {
+		return &ipAddresses{Controller: Controller{client: stub, apiPath: IPAddressesAPIPath, params: ParamsBuilder()}}
+	}
+
+	t.Run("existing reservation for mac", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		res, err := newClient(stub).Reserve(ctx, ReserveOptions{Subnet: "10.0.0.0/24", MAC: "52:54:00:AA:BB:CC"})
+		assert.Nil(t, err)
+		assert.Equal(t, res.IP().String(), "10.0.0.20")
+		assert.Empty(t, stub.sent(http.MethodPost))
+	})
+
+	t.Run("existing reservation for hostname", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		res, err := newClient(stub).Reserve(ctx, ReserveOptions{Subnet: "10.0.0.0/24", Hostname: "vip"})
+		assert.Nil(t, err)
+		assert.Equal(t, res.IP().String(), "10.0.0.21")
+		assert.Empty(t, stub.sent(http.MethodPost))
+	})
+
+	t.Run("existing reservation on subnet given by id", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		res, err := newClient(stub).Reserve(ctx, ReserveOptions{Subnet: "7", MAC: "52:54:00:aa:bb:cc"})
+		assert.Nil(t, err)
+		assert.Equal(t, res.IP().String(), "10.0.0.20")
+	})
+
+	t.Run("existing reservation on another subnet", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		_, err := newClient(stub).Reserve(ctx, ReserveOptions{Subnet: "10.1.0.0/24", MAC: "52:54:00:aa:bb:cc"})
+		assert.NotNil(t, err)
+		assert.Empty(t, stub.sent(http.MethodPost))
+	})
+
+	t.Run("sticky address isn't a reservation", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		res, err := newClient(stub).Reserve(ctx, ReserveOptions{Subnet: "10.0.0.0/24", MAC: "52:54:00:dd:ee:ff"})
+		assert.Nil(t, err)
+		assert.Equal(t, res.IP().String(), "10.0.0.30")
+		assert.Len(t, stub.sent(http.MethodPost), 1)
+	})
+
+	t.Run("hostname reserved with another ip", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		_, err := newClient(stub).Reserve(ctx, ReserveOptions{IP: "10.0.0.22", Hostname: "vip"})
+		assert.NotNil(t, err)
+		assert.Empty(t, stub.sent(http.MethodPost))
+	})
+
+	t.Run("new reservation", func(t *testing.T) {
+		stub := newReserveStubClient(testOwnedIPAddresses)
+		res, err := newClient(stub).Reserve(ctx, ReserveOptions{Subnet: "10.0.0.0/24", Hostname: "vip2", Comment: "keepalived"})
+		assert.Nil(t, err)
+		assert.Equal(t, res.IP().String(), "10.0.0.30")
+		posts := stub.sent(http.MethodPost)
+		assert.Len(t, posts, 1)
+		assert.Equal(t, posts[0].params.Get(Operation), OperationReserveIPAddress)
+		assert.Equal(t, posts[0].params.Get(CommentKey), "keepalived")
+	})
+}
+
+func TestIPAddresses_Reserve(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a managed subnet that has free addresses
+	subnetCIDR := "REPLACE_WITH_SUBNET_CIDR"
+
+	t.Run("reserve without subnet or ip", func(t *testing.T) {
+		res, err := c.IPAddresses().Reserve(ctx, ReserveOptions{Hostname: "vip-test"})
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("reserve twice and release", func(t *testing.T) {
+		if subnetCIDR == "REPLACE_WITH_SUBNET_CIDR" {
+			t.Skip("Please replace placeholder subnet CIDR")
+			return
+		}
+
+		opts := ReserveOptions{Subnet: subnetCIDR, Hostname: "vip-test", Comment: "created by tests"}
+		res, err := c.IPAddresses().Reserve(ctx, opts)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.AllocType(), IPAllocTypeUserReserved)
+		assert.NotEmpty(t, res.Owner())
+		assert.False(t, res.Created().IsZero())
+
+		again, err := c.IPAddresses().Reserve(ctx, opts)
+		assert.Nil(t, err)
+		assert.True(t, again.IP().Equal(res.IP()))
+
+		err = c.IPAddresses().Release(ctx, res.IP().String())
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+const (
+	IPRangesAPIPath      = "/ipranges/"
+	IPRangeAPIPathFormat = "/ipranges/%d/"
+)
+
+type IPRanges interface {
+	List(ctx context.Context) ([]IPRange, error)
+	// ListBySubnet returns the ranges carved out of the given subnet
+	ListBySubnet(ctx context.Context, subnetID int) ([]IPRange, error)
+	IPRange(id int) IPRange
+	// Builder returns a builder for a new range. Ranges overlapping an existing
+	// range are rejected before MAAS is called.
+	Builder() IPRangeBuilder
+}
+
+type IPRange interface {
+	Get(ctx context.Context) (IPRange, error)
+	Delete(ctx context.Context) error
+	// Modifier returns a modifier for the range. Like the builder, it rejects
+	// ranges that would overlap another range.
+	Modifier() IPRangeModifier
+	ID() int
+	// Type returns IPRangeTypeDynamic or IPRangeTypeReserved
+	Type() string
+	StartIP() net.IP
+	EndIP() net.IP
+	Comment() string
+	// Owner returns the username of the user who created the range
+	Owner() string
+	Subnet() Subnet
+}
+
+type IPRangeBuilder interface {
+	// WithType sets IPRangeTypeDynamic or IPRangeTypeReserved, MAAS defaults to reserved
+	WithType(rangeType string) IPRangeBuilder
+	WithStartIP(ip string) IPRangeBuilder
+	WithEndIP(ip string) IPRangeBuilder
+	// WithSubnet sets the subnet, which MAAS otherwise infers from the addresses
+	WithSubnet(subnetID int) IPRangeBuilder
+	WithComment(comment string) IPRangeBuilder
+	Create(ctx context.Context) (IPRange, error)
+}
+
+type IPRangeModifier interface {
+	SetStartIP(ip string) IPRangeModifier
+	SetEndIP(ip string) IPRangeModifier
+	SetComment(comment string) IPRangeModifier
+	Update(ctx context.Context) (IPRange, error)
+}
+
+type ipRanges struct {
+	Controller
+}
+
+func (rs *ipRanges) List(ctx context.Context) ([]IPRange, error) {
+	obj, err := listIPRanges(ctx, rs.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipRangeStructSliceToInterface(obj, rs.client), nil
+}
+
+func (rs *ipRanges) ListBySubnet(ctx context.Context, subnetID int) ([]IPRange, error) {
+	obj, err := listIPRanges(ctx, rs.client)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*ipRange
+	for _, r := range obj {
+		if r.subnet != nil && r.subnet.id == subnetID {
+			out = append(out, r)
+		}
+	}
+
+	return ipRangeStructSliceToInterface(out, rs.client), nil
+}
+
+func (rs *ipRanges) IPRange(id int) IPRange {
+	return ipRangeStructToInterface(&ipRange{id: id}, rs.client)
+}
+
+func (rs *ipRanges) Builder() IPRangeBuilder {
+	rs.params.Reset()
+	return rs
+}
+
+func (rs *ipRanges) WithType(rangeType string) IPRangeBuilder {
+	rs.params.Set(TypeKey, rangeType)
+	return rs
+}
+
+func (rs *ipRanges) WithStartIP(ip string) IPRangeBuilder {
+	rs.params.Set(StartIPKey, ip)
+	return rs
+}
+
+func (rs *ipRanges) WithEndIP(ip string) IPRangeBuilder {
+	rs.params.Set(EndIPKey, ip)
+	return rs
+}
+
+func (rs *ipRanges) WithSubnet(subnetID int) IPRangeBuilder {
+	rs.params.Set(SubnetKey, strconv.Itoa(subnetID))
+	return rs
+}
+
+func (rs *ipRanges) WithComment(comment string) IPRangeBuilder {
+	rs.params.Set(CommentKey, comment)
+	return rs
+}
+
+func (rs *ipRanges) Create(ctx context.Context) (IPRange, error) {
+	values := rs.params.Values()
+	if rangeType := values.Get(TypeKey); rangeType != "" && rangeType != IPRangeTypeDynamic && rangeType != IPRangeTypeReserved {
+		return nil, fmt.Errorf("invalid IP range type %q", rangeType)
+	}
+
+	err := checkIPRangeOverlap(ctx, rs.client, 0, values.Get(StartIPKey), values.Get(EndIPKey))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := rs.client.Post(ctx, rs.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *ipRange
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return ipRangeStructToInterface(obj, rs.client), nil
+}
+
+func listIPRanges(ctx context.Context, client Client) ([]*ipRange, error) {
+	res, err := client.Get(ctx, IPRangesAPIPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*ipRange
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// checkIPRangeOverlap returns an error if start and end don't form a valid range, or if
+// the range overlaps any existing range other than the one with ID excludeID (0 for none)
+func checkIPRangeOverlap(ctx context.Context, client Client, excludeID int, start, end string) error {
+	startIP, endIP := net.ParseIP(start), net.ParseIP(end)
+	if startIP == nil {
+		return fmt.Errorf("invalid start IP %q", start)
+	}
+	if endIP == nil {
+		return fmt.Errorf("invalid end IP %q", end)
+	}
+	if (startIP.To4() == nil) != (endIP.To4() == nil) {
+		return fmt.Errorf("start IP %s and end IP %s are not in the same address family", startIP, endIP)
+	}
+	if bytes.Compare(startIP.To16(), endIP.To16()) > 0 {
+		return fmt.Errorf("start IP %s is after end IP %s", startIP, endIP)
+	}
+
+	existing, err := listIPRanges(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range existing {
+		if r.id == excludeID || r.startIP == nil || r.endIP == nil {
+			continue
+		}
+		if bytes.Compare(startIP.To16(), r.endIP.To16()) <= 0 && bytes.Compare(r.startIP.To16(), endIP.To16()) <= 0 {
+			return fmt.Errorf("IP range %s-%s overlaps %s range %d (%s-%s)", startIP, endIP, r.rangeType, r.id, r.startIP, r.endIP)
+		}
+	}
+
+	return nil
+}
+
+func ipRangeStructSliceToInterface(in []*ipRange, client Client) []IPRange {
+	var out []IPRange
+	for _, r := range in {
+		out = append(out, ipRangeStructToInterface(r, client))
+	}
+	return out
+}
+
+func ipRangeStructToInterface(in *ipRange, client Client) IPRange {
+	in.client = client
+	in.apiPath = fmt.Sprintf(IPRangeAPIPathFormat, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type ipRange struct {
+	Controller
+	id        int
+	rangeType string
+	startIP   net.IP
+	endIP     net.IP
+	comment   string
+	owner     string
+	subnet    *subnet
+}
+
+func (r *ipRange) Get(ctx context.Context) (IPRange, error) {
+	res, err := r.client.Get(ctx, r.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *ipRange) Delete(ctx context.Context) error {
+	res, err := r.client.Delete(ctx, r.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (r *ipRange) Modifier() IPRangeModifier {
+	r.params.Reset()
+	return r
+}
+
+func (r *ipRange) SetStartIP(ip string) IPRangeModifier {
+	r.params.Set(StartIPKey, ip)
+	return r
+}
+
+func (r *ipRange) SetEndIP(ip string) IPRangeModifier {
+	r.params.Set(EndIPKey, ip)
+	return r
+}
+
+func (r *ipRange) SetComment(comment string) IPRangeModifier {
+	r.params.Set(CommentKey, comment)
+	return r
+}
+
+func (r *ipRange) Update(ctx context.Context) (IPRange, error) {
+	values := r.params.Values()
+	start, end := values.Get(StartIPKey), values.Get(EndIPKey)
+	if start != "" || end != "" {
+		if r.startIP == nil || r.endIP == nil {
+			if _, err := r.Get(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if start == "" {
+			start = r.startIP.String()
+		}
+		if end == "" {
+			end = r.endIP.String()
+		}
+		if err := checkIPRangeOverlap(ctx, r.client, r.id, start, end); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := r.client.PutParams(ctx, r.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *ipRange) ID() int {
+	return r.id
+}
+
+func (r *ipRange) Type() string {
+	return r.rangeType
+}
+
+func (r *ipRange) StartIP() net.IP {
+	return r.startIP
+}
+
+func (r *ipRange) EndIP() net.IP {
+	return r.endIP
+}
+
+func (r *ipRange) Comment() string {
+	return r.comment
+}
+
+func (r *ipRange) Owner() string {
+	return r.owner
+}
+
+func (r *ipRange) Subnet() Subnet {
+	if r.subnet == nil {
+		return nil
+	}
+	return subnetStructToInterface(r.subnet, r.client)
+}
+
+func (r *ipRange) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID      int             `json:"id"`
+		Type    string          `json:"type"`
+		StartIP string          `json:"start_ip"`
+		EndIP   string          `json:"end_ip"`
+		Comment string          `json:"comment"`
+		User    json.RawMessage `json:"user"`
+		Subnet  *subnet         `json:"subnet"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	r.id = des.ID
+	r.rangeType = des.Type
+	r.startIP = net.ParseIP(des.StartIP)
+	r.endIP = net.ParseIP(des.EndIP)
+	r.comment = des.Comment
+	r.subnet = des.Subnet
+	r.owner, err = unmarshalUsername(des.User)
+
+	return err
+}
+
+func NewIPRangesClient(client Client) IPRanges {
+	return &ipRanges{
+		Controller: Controller{
+			client:  client,
+			apiPath: IPRangesAPIPath,
+			params:  ParamsBuilder(),
+		},
+	}
+}
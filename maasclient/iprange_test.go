@@ -0,0 +1,172 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testIPRanges = `[
+	{"id": 1, "type": "dynamic", "start_ip": "10.0.0.100", "end_ip": "10.0.0.199", "comment": "",
+	 "user": {"username": "admin"}, "subnet": {"id": 3, "cidr": "10.0.0.0/24"}},
+	{"id": 2, "type": "reserved", "start_ip": "10.0.0.10", "end_ip": "10.0.0.19", "comment": "k8s vips",
+	 "user": "admin", "subnet": {"id": 3, "cidr": "10.0.0.0/24"}},
+	{"id": 3, "type": "reserved", "start_ip": "10.1.0.10", "end_ip": "10.1.0.19", "comment": "",
+	 "user": null, "subnet": {"id": 4, "cidr": "10.1.0.0/24"}}
+]`
+
+// newIPRangeStubClient serves testIPRanges and answers created or updated ranges
+func newIPRangeStubClient() *stubClient {
+	return newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			if req.path == IPRangesAPIPath {
+				return stubResponse(http.StatusOK, testIPRanges)
+			}
+			return stubResponse(http.StatusOK, `{"id": 2, "type": "reserved", "start_ip": "10.0.0.10", "end_ip": "10.0.0.19"}`)
+		},
+		http.MethodPost: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 4}`)
+		},
+		http.MethodPut: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 2}`)
+		},
+	})
+}
+
+// ipRangeWrites returns the created and updated ranges sent to client
+func ipRangeWrites(client *stubClient) []stubRequest {
+	return append(client.sent(http.MethodPost), client.sent(http.MethodPut)...)
+}
+
+func TestIPRange_Unmarshal(t *testing.T) {
+	var obj []*ipRange
+	err := json.Unmarshal([]byte(testIPRanges), &obj)
+	assert.Nil(t, err)
+	assert.Len(t, obj, 3)
+	assert.Equal(t, obj[0].Type(), IPRangeTypeDynamic)
+	assert.Equal(t, obj[0].Owner(), "admin")
+	assert.Equal(t, obj[1].Owner(), "admin")
+	assert.Empty(t, obj[2].Owner())
+	assert.Equal(t, obj[1].StartIP().String(), "10.0.0.10")
+	assert.Equal(t, obj[1].Subnet().ID(), 3)
+}
+
+func TestIPRanges_Overlap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("list by subnet", func(t *testing.T) {
+		res, err := NewIPRangesClient(newIPRangeStubClient()).ListBySubnet(ctx, 3)
+		assert.Nil(t, err)
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("overlapping range is rejected", func(t *testing.T) {
+		client := newIPRangeStubClient()
+		res, err := NewIPRangesClient(client).Builder().
+			WithType(IPRangeTypeReserved).
+			WithStartIP("10.0.0.150").
+			WithEndIP("10.0.0.250").
+			Create(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+		assert.Contains(t, err.Error(), "overlaps dynamic range 1")
+		assert.Empty(t, ipRangeWrites(client))
+	})
+
+	t.Run("inverted range is rejected", func(t *testing.T) {
+		client := newIPRangeStubClient()
+		_, err := NewIPRangesClient(client).Builder().WithStartIP("10.0.0.50").WithEndIP("10.0.0.40").Create(ctx)
+		assert.NotNil(t, err)
+		assert.Empty(t, ipRangeWrites(client))
+	})
+
+	t.Run("adjacent range is created", func(t *testing.T) {
+		client := newIPRangeStubClient()
+		res, err := NewIPRangesClient(client).Builder().
+			WithStartIP("10.0.0.200").
+			WithEndIP("10.0.0.210").
+			WithComment("load balancers").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Len(t, ipRangeWrites(client), 1)
+		assert.Equal(t, ipRangeWrites(client)[0].params.Get(CommentKey), "load balancers")
+	})
+
+	t.Run("update ignores the range itself", func(t *testing.T) {
+		client := newIPRangeStubClient()
+		r := NewIPRangesClient(client).IPRange(2)
+
+		_, err := r.Modifier().SetEndIP("10.0.0.29").Update(ctx)
+		assert.Nil(t, err)
+
+		_, err = r.Modifier().SetEndIP("10.0.0.100").Update(ctx)
+		assert.NotNil(t, err)
+		assert.Len(t, ipRangeWrites(client), 1)
+	})
+}
+
+func TestIPRanges(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a managed subnet that has 10.0.0.240-10.0.0.250 free
+	subnetCIDR := "REPLACE_WITH_SUBNET_CIDR"
+
+	t.Run("create, update and delete reserved range", func(t *testing.T) {
+		if subnetCIDR == "REPLACE_WITH_SUBNET_CIDR" {
+			t.Skip("Please replace placeholder subnet CIDR")
+			return
+		}
+
+		subnetID, err := c.Subnets().GetIDByCIDR(ctx, subnetCIDR)
+		assert.Nil(t, err)
+
+		res, err := c.IPRanges().Builder().
+			WithType(IPRangeTypeReserved).
+			WithStartIP("10.0.0.240").
+			WithEndIP("10.0.0.245").
+			WithSubnet(subnetID).
+			WithComment("k8s vips").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Subnet().ID(), subnetID)
+		assert.NotEmpty(t, res.Owner())
+
+		_, err = c.IPRanges().Builder().WithStartIP("10.0.0.245").WithEndIP("10.0.0.250").Create(ctx)
+		assert.NotNil(t, err)
+
+		res, err = res.Modifier().SetEndIP("10.0.0.250").Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.EndIP().String(), "10.0.0.250")
+
+		list, err := c.IPRanges().ListBySubnet(ctx, subnetID)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, list)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
@@ -37,6 +37,14 @@ type Machine interface {
 	Releaser() MachineReleaser
 	Modifier() MachineModifier
 	Deployer() MachineDeployer
+	// RAIDs returns a controller for the machine's software RAID devices
+	RAIDs() RAIDs
+	// VolumeGroups returns a controller for the machine's LVM volume groups
+	VolumeGroups() VolumeGroups
+	// BcacheCacheSets returns a controller for the machine's bcache cache sets
+	BcacheCacheSets() BcacheCacheSets
+	// Bcaches returns a controller for the machine's bcache devices
+	Bcaches() Bcaches
 	SystemID() string
 	FQDN() string
 	Zone() Zone
@@ -67,6 +75,23 @@ type Machine interface {
 	Tags() []string
 	// Parent returns the parent system_id when power_type is "lxd", or empty string otherwise
 	Parent() string
+	// DefaultGateways returns the links currently providing the IPv4 and IPv6 default routes
+	DefaultGateways() DefaultGateways
+	// Details returns the lshw and lldp XML captured during commissioning
+	Details(ctx context.Context) (*MachineDetails, error)
+}
+
+// DefaultGateways reports the default route of a machine per address family
+type DefaultGateways struct {
+	IPv4 DefaultGateway
+	IPv6 DefaultGateway
+}
+
+// DefaultGateway identifies the gateway and interface link providing a default route.
+// Both fields are empty when the machine has no default route for the address family.
+type DefaultGateway struct {
+	GatewayIP net.IP
+	LinkID    string
 }
 
 type PowerManagerOn interface {
@@ -86,6 +111,8 @@ type MachineReleaser interface {
 type MachineModifier interface {
 	SetSwapSize(size int) MachineModifier
 	SetHostname(hostname string) MachineModifier
+	// SetPool moves the machine to the resource pool with the given name
+	SetPool(pool string) MachineModifier
 	Update(ctx context.Context) (Machine, error)
 }
 
@@ -256,6 +283,7 @@ type machine struct {
 	tags              []string // Tag names applied to the machine (if provided by MAAS)
 	parentSystemID    string   // Parent system_id for LXD VMs
 	ephemeralDeploy   bool     // Machine will be deployed in memory even if it has disks
+	defaultGateways   DefaultGateways
 }
 
 func (m *machine) PowerManagerOn() PowerManagerOn {
@@ -337,6 +365,11 @@ func (m *machine) SetHostname(hostname string) MachineModifier {
 	return m
 }
 
+func (m *machine) SetPool(pool string) MachineModifier {
+	m.params.Set(PoolLabel, pool)
+	return m
+}
+
 func (m *machine) Update(ctx context.Context) (Machine, error) {
 	res, err := m.client.PutParams(ctx, m.apiPath, m.params.Values())
 	if err != nil {
@@ -382,6 +415,22 @@ func (m *machine) Releaser() MachineReleaser {
 	return m
 }
 
+func (m *machine) RAIDs() RAIDs {
+	return newRAIDsClient(m.client, m.systemID)
+}
+
+func (m *machine) VolumeGroups() VolumeGroups {
+	return newVolumeGroupsClient(m.client, m.systemID)
+}
+
+func (m *machine) BcacheCacheSets() BcacheCacheSets {
+	return newBcacheCacheSetsClient(m.client, m.systemID)
+}
+
+func (m *machine) Bcaches() Bcaches {
+	return newBcachesClient(m.client, m.systemID)
+}
+
 func (m *machine) Get(ctx context.Context) (Machine, error) {
 	res, err := m.client.Get(ctx, m.apiPath, m.params.Values())
 	if err != nil {
@@ -400,6 +449,23 @@ func (m *machine) Delete(ctx context.Context) error {
 	return unMarshalJson(res, nil)
 }
 
+func (m *machine) Details(ctx context.Context) (*MachineDetails, error) {
+	params := url.Values{}
+	params.Set(Operation, OperationDetails)
+
+	res, err := m.client.Get(ctx, m.apiPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := readBody(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMachineDetails(body)
+}
+
 func (m *machine) Release(ctx context.Context) (Machine, error) {
 	res, err := m.client.Post(ctx, m.apiPath, m.params.Values())
 	if err != nil {
@@ -418,7 +484,10 @@ func (m *machine) FQDN() string {
 }
 
 func (m *machine) Zone() Zone {
-	return m.zone
+	if m.zone == nil {
+		return nil
+	}
+	return zoneStructToInterface(m.zone, m.client)
 }
 
 func (m *machine) PowerState() string {
@@ -509,6 +578,11 @@ func (m *machine) Parent() string {
 	return ""
 }
 
+// DefaultGateways returns the links currently providing the IPv4 and IPv6 default routes
+func (m *machine) DefaultGateways() DefaultGateways {
+	return m.defaultGateways
+}
+
 func (m *machine) UnmarshalJSON(data []byte) error {
 	des := &struct {
 		SystemID      string        `json:"system_id"`
@@ -534,8 +608,12 @@ func (m *machine) UnmarshalJSON(data []byte) error {
 		Parent struct {
 			SystemID string `json:"system_id"`
 		} `json:"parent"`
-		TagNames  []string `json:"tag_names"`
-		TagsField []string `json:"tags"`
+		TagNames        []string `json:"tag_names"`
+		TagsField       []string `json:"tags"`
+		DefaultGateways struct {
+			IPv4 defaultGatewayDetails `json:"ipv4"`
+			IPv6 defaultGatewayDetails `json:"ipv6"`
+		} `json:"default_gateways"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -560,6 +638,10 @@ func (m *machine) UnmarshalJSON(data []byte) error {
 	m.memory = des.Memory
 	m.storageMBDecimal = des.Storage
 	m.parentSystemID = des.Parent.SystemID
+	m.defaultGateways = DefaultGateways{
+		IPv4: des.DefaultGateways.IPv4.toDefaultGateway(),
+		IPv6: des.DefaultGateways.IPv6.toDefaultGateway(),
+	}
 
 	// Populate tags if present under either field name
 	if len(des.TagsField) > 0 {
@@ -584,6 +666,23 @@ func (m *machine) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// defaultGatewayDetails mirrors one entry of the machine's default_gateways field
+type defaultGatewayDetails struct {
+	GatewayIP *string `json:"gateway_ip"`
+	LinkID    *int    `json:"link_id"`
+}
+
+func (d defaultGatewayDetails) toDefaultGateway() DefaultGateway {
+	var gw DefaultGateway
+	if d.GatewayIP != nil {
+		gw.GatewayIP = net.ParseIP(*d.GatewayIP)
+	}
+	if d.LinkID != nil {
+		gw.LinkID = strconv.Itoa(*d.LinkID)
+	}
+	return gw
+}
+
 func NewMachinesClient(client *authenticatedClient) Machines {
 	return &machines{Controller{
 		client:  client,
@@ -18,6 +18,7 @@ package maasclient
 
 import (
 	"context"
+	"encoding/json"
 	"math/rand"
 	"os"
 	"testing"
@@ -159,3 +160,23 @@ func TestClient_UpdateMachine(t *testing.T) {
 	assert.Equal(t, res.SwapSize(), 10)
 
 }
+
+func TestMachine_DefaultGateways(t *testing.T) {
+	data := `{
+		"system_id": "e37xxm",
+		"default_gateways": {
+			"ipv4": {"gateway_ip": "10.0.0.1", "link_id": 42},
+			"ipv6": {"gateway_ip": null, "link_id": null}
+		}
+	}`
+
+	var m *machine
+	err := json.Unmarshal([]byte(data), &m)
+	assert.Nil(t, err)
+
+	gateways := m.DefaultGateways()
+	assert.Equal(t, gateways.IPv4.GatewayIP.String(), "10.0.0.1")
+	assert.Equal(t, gateways.IPv4.LinkID, "42")
+	assert.Nil(t, gateways.IPv6.GatewayIP)
+	assert.Empty(t, gateways.IPv6.LinkID)
+}
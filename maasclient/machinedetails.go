@@ -0,0 +1,60 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MachineDetails holds the XML captured by the lshw and lldp commissioning scripts, either
+// is nil when MAAS has no output for it
+type MachineDetails struct {
+	LSHW []byte `bson:"lshw"`
+	LLDP []byte `bson:"lldp"`
+}
+
+// parseMachineDetails decodes the details op response. MAAS answers with a BSON document
+// mapping lshw and lldp to binary values, JSON is accepted for captured fixtures.
+func parseMachineDetails(data []byte) (*MachineDetails, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var des map[string]*string
+		if err := json.Unmarshal(trimmed, &des); err != nil {
+			return nil, err
+		}
+		details := &MachineDetails{}
+		if v := des["lshw"]; v != nil {
+			details.LSHW = []byte(*v)
+		}
+		if v := des["lldp"]; v != nil {
+			details.LLDP = []byte(*v)
+		}
+		return details, nil
+	}
+
+	if err := bson.Raw(data).Validate(); err != nil {
+		return nil, err
+	}
+	details := &MachineDetails{}
+	if err := bson.Unmarshal(data, details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
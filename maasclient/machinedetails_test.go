@@ -0,0 +1,146 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// encodeDetailsBSON builds the BSON document returned by the details op, nil values are
+// encoded as null
+func encodeDetailsBSON(values map[string][]byte) []byte {
+	doc := bson.D{}
+	for _, name := range []string{"lldp", "lshw"} {
+		if value, ok := values[name]; ok {
+			doc = append(doc, bson.E{Key: name, Value: value})
+		}
+	}
+	data, _ := bson.Marshal(doc)
+	return data
+}
+
+// bsonDocument wraps raw elements in a BSON document, size is the declared document
+// size or 0 to compute it
+func bsonDocument(size int32, elements ...[]byte) []byte {
+	body := bytes.Join(elements, nil)
+	if size == 0 {
+		size = int32(len(body) + 5)
+	}
+	var doc bytes.Buffer
+	_ = binary.Write(&doc, binary.LittleEndian, size)
+	doc.Write(body)
+	doc.WriteByte(0)
+	return doc.Bytes()
+}
+
+// bsonElement encodes an element with the given type, name and raw value
+func bsonElement(kind bsontype.Type, name string, value []byte) []byte {
+	return append(append([]byte{byte(kind)}, append([]byte(name), 0)...), value...)
+}
+
+// bsonLength encodes a BSON int32 length followed by data
+func bsonLength(length int32, data ...byte) []byte {
+	var b bytes.Buffer
+	_ = binary.Write(&b, binary.LittleEndian, length)
+	b.Write(data)
+	return b.Bytes()
+}
+
+func TestParseMachineDetails(t *testing.T) {
+	details, err := parseMachineDetails(encodeDetailsBSON(map[string][]byte{
+		"lshw": []byte(lshwFixture),
+		"lldp": nil,
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, string(details.LSHW), lshwFixture)
+	assert.Nil(t, details.LLDP)
+
+	details, err = parseMachineDetails([]byte(`{"lshw": "<list/>", "lldp": null}`))
+	assert.Nil(t, err)
+	assert.Equal(t, string(details.LSHW), "<list/>")
+	assert.Nil(t, details.LLDP)
+
+	_, err = parseMachineDetails([]byte{0x10, 0, 0, 0, 0})
+	assert.NotNil(t, err)
+}
+
+func TestParseMachineDetails_BSON(t *testing.T) {
+	valid := encodeDetailsBSON(map[string][]byte{"lshw": []byte("<list/>")})
+
+	t.Run("valid documents", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			data    []byte
+			details *MachineDetails
+		}{
+			{"binary", valid, &MachineDetails{LSHW: []byte("<list/>")}},
+			{"empty document", bsonDocument(0), &MachineDetails{}},
+			{"null", bsonDocument(0, bsonElement(bsontype.Null, "lldp", nil)), &MachineDetails{}},
+			{"string", bsonDocument(0, bsonElement(bsontype.String, "lshw", bsonLength(8, []byte("<list/>\x00")...))),
+				&MachineDetails{LSHW: []byte("<list/>")}},
+			{"empty binary", bsonDocument(0, bsonElement(bsontype.Binary, "lshw", bsonLength(0, 0))),
+				&MachineDetails{LSHW: []byte{}}},
+			{"old binary subtype", bsonDocument(0, bsonElement(bsontype.Binary, "lshw",
+				bsonLength(11, append([]byte{bsontype.BinaryBinaryOld}, bsonLength(7, []byte("<list/>")...)...)...))),
+				&MachineDetails{LSHW: []byte("<list/>")}},
+			{"other keys", bsonDocument(0, bsonElement(bsontype.Int32, "version", bsonLength(1))), &MachineDetails{}},
+		}
+
+		for _, tc := range tests {
+			details, err := parseMachineDetails(tc.data)
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.details, details, tc.name)
+		}
+	})
+
+	t.Run("malformed documents", func(t *testing.T) {
+		tests := []struct {
+			name string
+			data []byte
+		}{
+			{"empty", nil},
+			{"shorter than a document", []byte{5, 0, 0, 0}},
+			{"truncated", valid[:len(valid)-3]},
+			{"size too small", bsonDocument(4)},
+			{"size past the end", bsonDocument(64, bsonElement(bsontype.Null, "lldp", nil))},
+			{"size not at the terminator", bsonDocument(8, bsonElement(bsontype.Null, "lldp", nil))},
+			{"negative size", bsonDocument(-1)},
+			{"unterminated name", bsonDocument(0, []byte{byte(bsontype.Null), 'l', 'l', 'd', 'p'})},
+			{"missing length", bsonDocument(0, bsonElement(bsontype.Binary, "lshw", []byte{1, 0}))},
+			{"binary length past the end", bsonDocument(0, bsonElement(bsontype.Binary, "lshw", bsonLength(64, 0, 'x')))},
+			{"binary length over the terminator", bsonDocument(0, bsonElement(bsontype.Binary, "lshw", bsonLength(2, 0, 'x')))},
+			{"negative binary length", bsonDocument(0, bsonElement(bsontype.Binary, "lshw", bsonLength(-1, 0, 'x')))},
+			{"string length past the end", bsonDocument(0, bsonElement(bsontype.String, "lshw", bsonLength(64, 'x', 0)))},
+			{"zero string length", bsonDocument(0, bsonElement(bsontype.String, "lshw", bsonLength(0, 'x', 0)))},
+			{"unterminated string", bsonDocument(0, bsonElement(bsontype.String, "lshw", bsonLength(2, 'x', 'y')))},
+			{"old binary subtype with wrong inner length", bsonDocument(0, bsonElement(bsontype.Binary, "lshw",
+				bsonLength(11, append([]byte{bsontype.BinaryBinaryOld}, bsonLength(9, []byte("<list/>")...)...)...)))},
+			{"unsupported type", bsonDocument(0, bsonElement(bsontype.Int32, "lshw", bsonLength(1)))},
+		}
+
+		for _, tc := range tests {
+			_, err := parseMachineDetails(tc.data)
+			assert.NotNil(t, err, tc.name)
+		}
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 )
 
 // NetworkInterfaces provides methods to interact with machine network interfaces
@@ -29,7 +31,8 @@ type NetworkInterfaces interface {
 	Get(ctx context.Context, systemID string) ([]NetworkInterface, error)
 	// Interface returns a NetworkInterface for a specific interface ID
 	Interface(systemID, interfaceID string) NetworkInterface
-	// SetBootInterfaceStaticIP sets a static IP on the boot interface directly
+	// SetBootInterfaceStaticIP sets a static IP on the boot interface directly,
+	// restoring the previous configuration if the new address can't be linked
 	SetBootInterfaceStaticIP(ctx context.Context, systemID, ipAddress string) error
 	// SetStaticIPOnInterfaceID sets a static IP on a specific interface by interface ID
 	SetStaticIPOnInterfaceID(ctx context.Context, systemID, interfaceID, ipAddress string) error
@@ -37,6 +40,18 @@ type NetworkInterfaces interface {
 	CreateBridge(ctx context.Context, systemID, bridgeName, parentInterfaceID string) (NetworkInterface, error)
 	// CreateBootInterfaceBridge creates a bridge on the machine's boot interface
 	CreateBootInterfaceBridge(ctx context.Context, systemID, bridgeName string) (NetworkInterface, error)
+	// CreateBond creates a bond interface over the specified parent interfaces
+	CreateBond(ctx context.Context, systemID, bondName string, parentInterfaceIDs []string, opts BondOptions) (NetworkInterface, error)
+	// CreateBootInterfaceBond creates a bond over the machine's boot interface and any additional parents
+	CreateBootInterfaceBond(ctx context.Context, systemID, bondName string, additionalParentIDs []string, opts BondOptions) (NetworkInterface, error)
+	// CreateVLANInterface creates a tagged VLAN interface on the specified parent interface.
+	// vlanID is the MAAS database ID of the VLAN, not its VID. An interface MAAS puts on another VLAN
+	// is deleted again, when that fails it is returned together with the error.
+	CreateVLANInterface(ctx context.Context, systemID, parentInterfaceID string, vlanID int, opts VLANInterfaceOptions) (NetworkInterface, error)
+	// CreateVLANInterfaceByVID resolves the VLAN by fabric name and VID, then creates the VLAN interface
+	CreateVLANInterfaceByVID(ctx context.Context, systemID, parentInterfaceID, fabricName string, vid int, opts VLANInterfaceOptions) (NetworkInterface, error)
+	// CreatePhysical creates a physical interface; MAAS allows this on devices and deployed machines
+	CreatePhysical(ctx context.Context, systemID, macAddress string, opts PhysicalInterfaceOptions) (NetworkInterface, error)
 }
 
 // NetworkInterface represents a single network interface on a machine
@@ -47,15 +62,32 @@ type NetworkInterface interface {
 	LinkSubnet(ctx context.Context, subnetID string, ipAddress string) error
 	// UnlinkSubnet unlinks a subnet from this interface
 	UnlinkSubnet(ctx context.Context, linkID string) error
-	// UpdateIPConfiguration updates an existing link's IP configuration directly
+	// UpdateIPConfiguration updates an existing link's IP configuration directly.
+	// If the new link can't be created the previous one is restored and an
+	// *IPConfigurationError describing both outcomes is returned.
 	UpdateIPConfiguration(ctx context.Context, config IPConfigurationUpdate) error
 	// SetStaticIP sets a static IP on the interface
 	// Handles two valid scenarios:
 	// 1. Interface has direct links - configures directly
 	// 2. Interface has children (bridge) - configures on child with links
+	// Rolls back like UpdateIPConfiguration on failure
 	SetStaticIP(ctx context.Context, ipAddress string) error
 	// SetDHCP sets the interface to use DHCP (handles existing links automatically)
+	// Rolls back like UpdateIPConfiguration on failure
 	SetDHCP(ctx context.Context, subnetID string) error
+	// Update changes the interface settings and returns the refreshed interface
+	Update(ctx context.Context, update InterfaceUpdate) (NetworkInterface, error)
+	// Delete removes the interface from the machine
+	Delete(ctx context.Context) error
+	// Disconnect removes all links and the VLAN from the interface and returns the refreshed interface
+	Disconnect(ctx context.Context) (NetworkInterface, error)
+	// AddTag adds a tag to the interface and returns the refreshed interface
+	AddTag(ctx context.Context, tag string) (NetworkInterface, error)
+	// RemoveTag removes a tag from the interface and returns the refreshed interface
+	RemoveTag(ctx context.Context, tag string) (NetworkInterface, error)
+	// SetDefaultGateway makes the gateway of the given link the machine's default route for
+	// its address family. An empty linkID lets MAAS pick the link when there is only one candidate.
+	SetDefaultGateway(ctx context.Context, linkID string) (NetworkInterface, error)
 
 	// Getters for interface properties
 	ID() string
@@ -63,8 +95,15 @@ type NetworkInterface interface {
 	Type() string
 	Enabled() bool
 	MACAddress() string
+	// EffectiveMTU returns the MTU in use, inherited from the VLAN when not set on the interface
+	EffectiveMTU() int
+	Tags() []string
 	Links() []NetworkInterfaceLink
 	Children() []string
+	// Parents returns the names of the interfaces this bond, bridge or VLAN interface is built on
+	Parents() []string
+	// BondParameters returns the bonding configuration, or nil if the interface is not a bond
+	BondParameters() *BondParameters
 	VLAN() VLAN
 }
 
@@ -76,6 +115,176 @@ type IPConfigurationUpdate struct {
 	SubnetID  *string // Required: subnet ID
 }
 
+// IPConfigurationError is returned when an IP reconfiguration fails after the
+// existing link was removed. It reports the original failure together with the
+// outcome of restoring the previous link.
+type IPConfigurationError struct {
+	Err         error // Failure that triggered the rollback
+	RollbackErr error // nil when the previous configuration was restored
+}
+
+func (e *IPConfigurationError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("failed to link new configuration: %v; rollback of previous configuration failed: %v", e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("failed to link new configuration: %v; previous configuration restored", e.Err)
+}
+
+func (e *IPConfigurationError) Unwrap() error {
+	return e.Err
+}
+
+// RolledBack returns true when the previous configuration was restored
+func (e *IPConfigurationError) RolledBack() bool {
+	return e.RollbackErr == nil
+}
+
+// BondOptions represents the optional parameters for creating a bond.
+// Zero values and nil pointers are left for MAAS to default.
+type BondOptions struct {
+	Mode           string // One of the BondMode* constants, MAAS defaults to balance-rr
+	MIIMon         *int   // Link monitoring frequency in milliseconds
+	DownDelay      *int   // Milliseconds to wait before disabling a failed slave
+	UpDelay        *int   // Milliseconds to wait before enabling a recovered slave
+	LACPRate       string // "fast" or "slow", 802.3ad only
+	XmitHashPolicy string // "layer2", "layer2+3", "layer3+4", "encap2+3" or "encap3+4"
+	MTU            *int
+	VLANID         *int   // Database ID of the untagged VLAN for the bond
+	MACAddress     string // Defaults to the MAC address of the first parent
+}
+
+func (o BondOptions) apply(params Params) {
+	if o.Mode != "" {
+		params.Set(BondModeKey, o.Mode)
+	}
+	if o.MIIMon != nil {
+		params.Set(BondMIIMonKey, strconv.Itoa(*o.MIIMon))
+	}
+	if o.DownDelay != nil {
+		params.Set(BondDownDelayKey, strconv.Itoa(*o.DownDelay))
+	}
+	if o.UpDelay != nil {
+		params.Set(BondUpDelayKey, strconv.Itoa(*o.UpDelay))
+	}
+	if o.LACPRate != "" {
+		params.Set(BondLACPRateKey, o.LACPRate)
+	}
+	if o.XmitHashPolicy != "" {
+		params.Set(BondXmitHashPolicyKey, o.XmitHashPolicy)
+	}
+	if o.MTU != nil {
+		params.Set(MTUKey, strconv.Itoa(*o.MTU))
+	}
+	if o.VLANID != nil {
+		params.Set(VLANKey, strconv.Itoa(*o.VLANID))
+	}
+	if o.MACAddress != "" {
+		params.Set(MACAddressKey, o.MACAddress)
+	}
+}
+
+// VLANInterfaceOptions represents the optional parameters for creating a VLAN interface
+type VLANInterfaceOptions struct {
+	Tags []string
+	MTU  *int
+}
+
+func (o VLANInterfaceOptions) apply(params Params) {
+	if len(o.Tags) > 0 {
+		params.Set(TagKey, strings.Join(o.Tags, ","))
+	}
+	if o.MTU != nil {
+		params.Set(MTUKey, strconv.Itoa(*o.MTU))
+	}
+}
+
+// PhysicalInterfaceOptions represents the optional parameters for creating a physical interface
+type PhysicalInterfaceOptions struct {
+	Name     string
+	Tags     []string
+	VLANID   *int // Database ID of the untagged VLAN, disconnected when nil
+	MTU      *int
+	AcceptRA *bool
+	Autoconf *bool
+}
+
+func (o PhysicalInterfaceOptions) apply(params Params) {
+	if o.Name != "" {
+		params.Set(NameKey, o.Name)
+	}
+	if len(o.Tags) > 0 {
+		params.Set(TagKey, strings.Join(o.Tags, ","))
+	}
+	if o.VLANID != nil {
+		params.Set(VLANKey, strconv.Itoa(*o.VLANID))
+	}
+	if o.MTU != nil {
+		params.Set(MTUKey, strconv.Itoa(*o.MTU))
+	}
+	if o.AcceptRA != nil {
+		params.Set(AcceptRAKey, strconv.FormatBool(*o.AcceptRA))
+	}
+	if o.Autoconf != nil {
+		params.Set(AutoconfKey, strconv.FormatBool(*o.Autoconf))
+	}
+}
+
+// InterfaceUpdate represents the parameters for updating an interface.
+// Nil fields are left unchanged.
+type InterfaceUpdate struct {
+	Name       *string
+	MACAddress *string
+	MTU        *int
+	Tags       []string // Replaces all tags when non-nil
+	AcceptRA   *bool
+	Autoconf   *bool
+	BridgeSTP  *bool // Bridge interfaces only
+	BridgeFD   *int  // Bridge forward delay in seconds, bridge interfaces only
+	// Bond parameters, bond interfaces only. The fields above take
+	// precedence over the MTU, VLANID and MACAddress set here.
+	Bond *BondOptions
+}
+
+func (u InterfaceUpdate) apply(params Params) {
+	if u.Bond != nil {
+		u.Bond.apply(params)
+	}
+	if u.Name != nil {
+		params.Set(NameKey, *u.Name)
+	}
+	if u.MACAddress != nil {
+		params.Set(MACAddressKey, *u.MACAddress)
+	}
+	if u.MTU != nil {
+		params.Set(MTUKey, strconv.Itoa(*u.MTU))
+	}
+	if u.Tags != nil {
+		params.Set(TagKey, strings.Join(u.Tags, ","))
+	}
+	if u.AcceptRA != nil {
+		params.Set(AcceptRAKey, strconv.FormatBool(*u.AcceptRA))
+	}
+	if u.Autoconf != nil {
+		params.Set(AutoconfKey, strconv.FormatBool(*u.Autoconf))
+	}
+	if u.BridgeSTP != nil {
+		params.Set(BridgeSTPKey, strconv.FormatBool(*u.BridgeSTP))
+	}
+	if u.BridgeFD != nil {
+		params.Set(BridgeFDKey, strconv.Itoa(*u.BridgeFD))
+	}
+}
+
+// BondParameters holds the bonding configuration MAAS reports for a bond interface
+type BondParameters struct {
+	Mode           string `json:"bond_mode"`
+	MIIMon         int    `json:"bond_miimon"`
+	DownDelay      int    `json:"bond_downdelay"`
+	UpDelay        int    `json:"bond_updelay"`
+	LACPRate       string `json:"bond_lacp_rate"`
+	XmitHashPolicy string `json:"bond_xmit_hash_policy"`
+}
+
 // NetworkInterfaceLink represents a link (IP configuration) on a network interface
 type NetworkInterfaceLink interface {
 	ID() string
@@ -97,8 +306,12 @@ type networkInterface struct {
 	ifType      string
 	enabled     bool
 	macAddress  string
+	mtu         int
+	tags        []string
 	links       []*networkInterfaceLink
 	children    []string
+	parents     []string
+	bondParams  *BondParameters
 	vlan        *vlan
 }
 
@@ -109,20 +322,10 @@ type networkInterfaceLink struct {
 	ipAddress net.IP
 }
 
-type vlan struct {
-	id         int
-	vid        int
-	name       string
-	fabricID   int
-	fabricName string
-	mtu        int
-	dhcpOn     bool
-}
-
 // NetworkInterfaces implementation
 func (ni *networkInterfaces) Get(ctx context.Context, systemID string) ([]NetworkInterface, error) {
 	path := fmt.Sprintf("/nodes/%s/interfaces/", systemID)
-	res, err := ni.client.Get(ctx, path, ni.params.Values())
+	res, err := ni.client.Get(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -223,22 +426,172 @@ func (ni *networkInterfaces) CreateBridge(ctx context.Context, systemID, bridgeN
 		return nil, fmt.Errorf("failed to parse bridge creation response: %w", err)
 	}
 
+	return ni.createdInterface(systemID, bridgeInterface), nil
+}
+
+// createdInterface wires a freshly created interface up for further API calls
+func (ni *networkInterfaces) createdInterface(systemID string, iface *networkInterface) NetworkInterface {
 	// Set system ID and client for the new interface
-	bridgeInterface.systemID = systemID
-	bridgeInterface.client = ni.client
-	bridgeInterface.params = ParamsBuilder()
+	iface.systemID = systemID
+	iface.client = ni.client
+	iface.params = ParamsBuilder()
 
 	// Set up the API path using the ID from the response
 	// The id field should now be populated from the JSON unmarshaling
-	if bridgeInterface.id != "" {
-		bridgeInterface.apiPath = fmt.Sprintf("/nodes/%s/interfaces/%s/", systemID, bridgeInterface.id)
-		bridgeInterface.interfaceID = bridgeInterface.id
+	if iface.id != "" {
+		iface.apiPath = fmt.Sprintf("/nodes/%s/interfaces/%s/", systemID, iface.id)
+		iface.interfaceID = iface.id
 	}
 
-	return bridgeInterface, nil
+	return iface
 }
 
 func (ni *networkInterfaces) CreateBootInterfaceBridge(ctx context.Context, systemID, bridgeName string) (NetworkInterface, error) {
+	bootInterfaceID, err := ni.bootInterfaceID(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create bridge on the boot interface
+	return ni.CreateBridge(ctx, systemID, bridgeName, bootInterfaceID)
+}
+
+func (ni *networkInterfaces) CreateBond(ctx context.Context, systemID, bondName string, parentInterfaceIDs []string, opts BondOptions) (NetworkInterface, error) {
+	if len(parentInterfaceIDs) == 0 {
+		return nil, fmt.Errorf("at least one parent interface is required to create bond %s", bondName)
+	}
+
+	// Set up parameters for bond creation
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationCreateBond)
+	ni.params.Set(NameKey, bondName)
+	for _, parentID := range parentInterfaceIDs {
+		ni.params.Add(ParentsKey, parentID)
+	}
+	opts.apply(ni.params)
+
+	path := fmt.Sprintf("/nodes/%s/interfaces/", systemID)
+	res, err := ni.client.Post(ctx, path, ni.params.Values())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bond %s on parents %v: %w", bondName, parentInterfaceIDs, err)
+	}
+
+	var bondInterface *networkInterface
+	err = unMarshalJson(res, &bondInterface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bond creation response: %w", err)
+	}
+
+	return ni.createdInterface(systemID, bondInterface), nil
+}
+
+func (ni *networkInterfaces) CreateBootInterfaceBond(ctx context.Context, systemID, bondName string, additionalParentIDs []string, opts BondOptions) (NetworkInterface, error) {
+	bootInterfaceID, err := ni.bootInterfaceID(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The boot interface goes first so the bond inherits its MAC address by default
+	parents := []string{bootInterfaceID}
+	for _, parentID := range additionalParentIDs {
+		if parentID != bootInterfaceID {
+			parents = append(parents, parentID)
+		}
+	}
+
+	return ni.CreateBond(ctx, systemID, bondName, parents, opts)
+}
+
+func (ni *networkInterfaces) CreateVLANInterface(ctx context.Context, systemID, parentInterfaceID string, vlanID int, opts VLANInterfaceOptions) (NetworkInterface, error) {
+	// Set up parameters for VLAN interface creation
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationCreateVLAN)
+	ni.params.Set(ParentKey, parentInterfaceID)
+	ni.params.Set(VLANKey, strconv.Itoa(vlanID))
+	opts.apply(ni.params)
+
+	path := fmt.Sprintf("/nodes/%s/interfaces/", systemID)
+	res, err := ni.client.Post(ctx, path, ni.params.Values())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VLAN %d interface on parent %s: %w", vlanID, parentInterfaceID, err)
+	}
+
+	var vlanInterface *networkInterface
+	err = unMarshalJson(res, &vlanInterface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse VLAN interface creation response: %w", err)
+	}
+
+	created := ni.createdInterface(systemID, vlanInterface)
+	if vlanInterface.vlan == nil || vlanInterface.vlan.ID() != vlanID {
+		// Remove the interface MAAS created so a retry doesn't fail as a duplicate
+		if err := created.Delete(ctx); err != nil {
+			return created, fmt.Errorf("VLAN interface %s was not created on VLAN %d and couldn't be removed: %w", vlanInterface.name, vlanID, err)
+		}
+		return nil, fmt.Errorf("VLAN interface %s was not created on VLAN %d", vlanInterface.name, vlanID)
+	}
+
+	return created, nil
+}
+
+func (ni *networkInterfaces) CreateVLANInterfaceByVID(ctx context.Context, systemID, parentInterfaceID, fabricName string, vid int, opts VLANInterfaceOptions) (NetworkInterface, error) {
+	vlanID, err := ni.vlanIDByVID(ctx, fabricName, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	return ni.CreateVLANInterface(ctx, systemID, parentInterfaceID, vlanID, opts)
+}
+
+// vlanIDByVID finds the database ID of the VLAN with the given VID on the named fabric
+func (ni *networkInterfaces) vlanIDByVID(ctx context.Context, fabricName string, vid int) (int, error) {
+	fabrics, err := NewFabricsClient(ni.client).List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, f := range fabrics {
+		if f.Name() != fabricName {
+			continue
+		}
+		for _, v := range f.VLANs() {
+			if v.VID() == vid {
+				return v.ID(), nil
+			}
+		}
+		return 0, fmt.Errorf("no VLAN with VID %d found on fabric %s", vid, fabricName)
+	}
+
+	return 0, fmt.Errorf("no fabric found with name %s", fabricName)
+}
+
+func (ni *networkInterfaces) CreatePhysical(ctx context.Context, systemID, macAddress string, opts PhysicalInterfaceOptions) (NetworkInterface, error) {
+	if macAddress == "" {
+		return nil, fmt.Errorf("MAC address is required to create a physical interface")
+	}
+
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationCreatePhysical)
+	ni.params.Set(MACAddressKey, macAddress)
+	opts.apply(ni.params)
+
+	path := fmt.Sprintf("/nodes/%s/interfaces/", systemID)
+	res, err := ni.client.Post(ctx, path, ni.params.Values())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create physical interface %s: %w", macAddress, err)
+	}
+
+	var physicalInterface *networkInterface
+	err = unMarshalJson(res, &physicalInterface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse physical interface creation response: %w", err)
+	}
+
+	return ni.createdInterface(systemID, physicalInterface), nil
+}
+
+// bootInterfaceID looks up the ID of the machine's boot interface
+func (ni *networkInterfaces) bootInterfaceID(ctx context.Context, systemID string) (string, error) {
 	// Get machine details to find boot interface ID
 	machineClient := &machine{
 		Controller: Controller{
@@ -251,21 +604,20 @@ func (ni *networkInterfaces) CreateBootInterfaceBridge(ctx context.Context, syst
 
 	machineDetails, err := machineClient.Get(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get machine details: %w", err)
+		return "", fmt.Errorf("failed to get machine details: %w", err)
 	}
 
 	bootInterfaceID := machineDetails.BootInterfaceID()
 	if bootInterfaceID == "" {
-		return nil, fmt.Errorf("no boot interface found for machine %s", systemID)
+		return "", fmt.Errorf("no boot interface found for machine %s", systemID)
 	}
 
-	// Create bridge on the boot interface
-	return ni.CreateBridge(ctx, systemID, bridgeName, bootInterfaceID)
+	return bootInterfaceID, nil
 }
 
 // NetworkInterface implementation
 func (ni *networkInterface) Get(ctx context.Context) (NetworkInterface, error) {
-	res, err := ni.client.Get(ctx, ni.apiPath, ni.params.Values())
+	res, err := ni.client.Get(ctx, ni.apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -331,33 +683,92 @@ func (ni *networkInterface) UpdateIPConfiguration(ctx context.Context, config IP
 		}
 	}
 
+	// Capture the current link so it can be restored if relinking fails
+	previous, err := ni.findLink(ctx, config.LinkID)
+	if err != nil {
+		return err
+	}
+
 	// MAAS doesn't have update_link operation, so we unlink and relink
 	// First unlink the existing configuration
-	err := ni.UnlinkSubnet(ctx, config.LinkID)
+	err = ni.UnlinkSubnet(ctx, config.LinkID)
 	if err != nil {
 		return fmt.Errorf("failed to unlink existing configuration: %w", err)
 	}
 
 	// Then link with new configuration
-	subnetID := *config.SubnetID
-
-	// For static mode, use the IP address; for DHCP, use empty string
+	// For static mode, use the IP address; for DHCP and other modes, let MAAS assign
+	ipAddress := ""
 	if config.Mode == ModeStatic {
-		ipAddress := *config.IPAddress
-		return ni.LinkSubnet(ctx, subnetID, ipAddress)
-	} else {
-		// For DHCP or other modes
-		ni.params.Reset()
-		ni.params.Set(Operation, OperationLinkSubnet)
-		ni.params.Set(SubnetKey, subnetID)
-		ni.params.Set(ModeKey, config.Mode)
+		ipAddress = *config.IPAddress
+	}
 
-		res, err := ni.client.Post(ctx, ni.apiPath, ni.params.Values())
-		if err != nil {
-			return err
+	err = ni.linkSubnetWithMode(ctx, config.Mode, *config.SubnetID, ipAddress)
+	if err == nil {
+		return nil
+	}
+
+	return &IPConfigurationError{
+		Err:         err,
+		RollbackErr: ni.restoreLink(ctx, previous),
+	}
+}
+
+// findLink returns the link with the given ID, refreshing the interface if it isn't known yet
+func (ni *networkInterface) findLink(ctx context.Context, linkID string) (*networkInterfaceLink, error) {
+	for _, link := range ni.links {
+		if link.id == linkID {
+			return link, nil
 		}
-		return unMarshalJson(res, nil)
 	}
+
+	if _, err := ni.Get(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get current configuration: %w", err)
+	}
+
+	for _, link := range ni.links {
+		if link.id == linkID {
+			return link, nil
+		}
+	}
+
+	return nil, fmt.Errorf("link %s not found on interface %s", linkID, ni.name)
+}
+
+// restoreLink re-creates a link that was removed by UpdateIPConfiguration
+func (ni *networkInterface) restoreLink(ctx context.Context, previous *networkInterfaceLink) error {
+	subnetID := ""
+	if previous.subnet != nil {
+		subnetID = strconv.Itoa(previous.subnet.ID())
+	}
+
+	// Only static links pin an address; MAAS reassigns it for the other modes
+	ipAddress := ""
+	if previous.mode == ModeStatic && previous.ipAddress != nil {
+		ipAddress = previous.ipAddress.String()
+	}
+
+	return ni.linkSubnetWithMode(ctx, previous.mode, subnetID, ipAddress)
+}
+
+// linkSubnetWithMode links a subnet using an explicit mode; subnetID and ipAddress are optional
+func (ni *networkInterface) linkSubnetWithMode(ctx context.Context, mode, subnetID, ipAddress string) error {
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationLinkSubnet)
+	ni.params.Set(ModeKey, mode)
+	if subnetID != "" {
+		ni.params.Set(SubnetKey, subnetID)
+	}
+	if ipAddress != "" {
+		ni.params.Set(IPAddressKey, ipAddress)
+	}
+
+	res, err := ni.client.Post(ctx, ni.apiPath, ni.params.Values())
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
 }
 
 func (ni *networkInterface) SetStaticIP(ctx context.Context, ipAddress string) error {
@@ -469,6 +880,70 @@ func (ni *networkInterface) SetDHCP(ctx context.Context, subnetID string) error
 	return ni.LinkSubnet(ctx, subnetID, "")
 }
 
+func (ni *networkInterface) Update(ctx context.Context, update InterfaceUpdate) (NetworkInterface, error) {
+	ni.params.Reset()
+	update.apply(ni.params)
+
+	res, err := ni.client.PutParams(ctx, ni.apiPath, ni.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return ni, unMarshalJson(res, ni)
+}
+
+func (ni *networkInterface) Delete(ctx context.Context) error {
+	res, err := ni.client.Delete(ctx, ni.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (ni *networkInterface) Disconnect(ctx context.Context) (NetworkInterface, error) {
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationDisconnect)
+
+	return ni.post(ctx)
+}
+
+func (ni *networkInterface) AddTag(ctx context.Context, tag string) (NetworkInterface, error) {
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationAddTag)
+	ni.params.Set(SingleTagKey, tag)
+
+	return ni.post(ctx)
+}
+
+func (ni *networkInterface) RemoveTag(ctx context.Context, tag string) (NetworkInterface, error) {
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationRemoveTag)
+	ni.params.Set(SingleTagKey, tag)
+
+	return ni.post(ctx)
+}
+
+func (ni *networkInterface) SetDefaultGateway(ctx context.Context, linkID string) (NetworkInterface, error) {
+	ni.params.Reset()
+	ni.params.Set(Operation, OperationSetDefaultGW)
+	if linkID != "" {
+		ni.params.Set(GatewayLinkIDKey, linkID)
+	}
+
+	return ni.post(ctx)
+}
+
+// post sends the prepared operation and refreshes the interface from the response
+func (ni *networkInterface) post(ctx context.Context) (NetworkInterface, error) {
+	res, err := ni.client.Post(ctx, ni.apiPath, ni.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return ni, unMarshalJson(res, ni)
+}
+
 // Getters
 func (ni *networkInterface) ID() string {
 	return ni.id
@@ -490,6 +965,14 @@ func (ni *networkInterface) MACAddress() string {
 	return ni.macAddress
 }
 
+func (ni *networkInterface) EffectiveMTU() int {
+	return ni.mtu
+}
+
+func (ni *networkInterface) Tags() []string {
+	return ni.tags
+}
+
 func (ni *networkInterface) Links() []NetworkInterfaceLink {
 	return networkInterfaceLinkSliceToInterface(ni.links)
 }
@@ -498,8 +981,19 @@ func (ni *networkInterface) Children() []string {
 	return ni.children
 }
 
+func (ni *networkInterface) Parents() []string {
+	return ni.parents
+}
+
+func (ni *networkInterface) BondParameters() *BondParameters {
+	return ni.bondParams
+}
+
 func (ni *networkInterface) VLAN() VLAN {
-	return ni.vlan
+	if ni.vlan == nil {
+		return nil
+	}
+	return vlanStructToInterface(ni.vlan, ni.client)
 }
 
 // JSON unmarshaling
@@ -511,8 +1005,12 @@ func (ni *networkInterface) UnmarshalJSON(data []byte) error {
 		Type       string                  `json:"type"`
 		Enabled    bool                    `json:"enabled"`
 		MACAddress string                  `json:"mac_address"`
+		MTU        int                     `json:"effective_mtu"`
+		Tags       []string                `json:"tags"`
 		Links      []*networkInterfaceLink `json:"links"`
 		Children   []string                `json:"children"`
+		Parents    []string                `json:"parents"`
+		Params     json.RawMessage         `json:"params"`
 		VLAN       *vlan                   `json:"vlan"`
 		*Alias
 	}{
@@ -529,10 +1027,23 @@ func (ni *networkInterface) UnmarshalJSON(data []byte) error {
 	ni.ifType = aux.Type
 	ni.enabled = aux.Enabled
 	ni.macAddress = aux.MACAddress
+	ni.mtu = aux.MTU
+	ni.tags = aux.Tags
 	ni.links = aux.Links
 	ni.children = aux.Children
+	ni.parents = aux.Parents
 	ni.vlan = aux.VLAN
 
+	// MAAS sends params as an empty string rather than an object when nothing is set
+	ni.bondParams = nil
+	if ni.ifType == "bond" && len(aux.Params) > 0 && aux.Params[0] == '{' {
+		params := &BondParameters{}
+		if err := json.Unmarshal(aux.Params, params); err != nil {
+			return err
+		}
+		ni.bondParams = params
+	}
+
 	return nil
 }
 
@@ -578,54 +1089,6 @@ func (link *networkInterfaceLink) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (v *vlan) UnmarshalJSON(data []byte) error {
-	aux := &struct {
-		ID         int    `json:"id"`
-		VID        int    `json:"vid"`
-		Name       string `json:"name"`
-		FabricID   int    `json:"fabric_id"`
-		FabricName string `json:"fabric"`
-		MTU        int    `json:"mtu"`
-		DHCPOn     bool   `json:"dhcp_on"`
-	}{}
-
-	err := json.Unmarshal(data, &aux)
-	if err != nil {
-		return err
-	}
-
-	v.id = aux.ID
-	v.vid = aux.VID
-	v.name = aux.Name
-	v.fabricID = aux.FabricID
-	v.fabricName = aux.FabricName
-	v.mtu = aux.MTU
-	v.dhcpOn = aux.DHCPOn
-
-	return nil
-}
-
-// VLAN interface implementation
-func (v *vlan) VID() int {
-	return v.vid
-}
-
-func (v *vlan) MTU() int {
-	return v.mtu
-}
-
-func (v *vlan) IsDHCPOn() bool {
-	return v.dhcpOn
-}
-
-func (v *vlan) FabricID() int {
-	return v.fabricID
-}
-
-func (v *vlan) FabricName() string {
-	return v.fabricName
-}
-
 // NetworkInterfaceLink implementation
 func (link *networkInterfaceLink) ID() string {
 	return link.id
@@ -657,6 +1120,9 @@ func networkInterfaceStructToInterface(in *networkInterface, client Client) Netw
 	// Set up the interface ID for API calls if available
 	if in.id != "" {
 		in.interfaceID = in.id
+		if in.systemID != "" && in.apiPath == "" {
+			in.apiPath = fmt.Sprintf("/nodes/%s/interfaces/%s/", in.systemID, in.id)
+		}
 	}
 	// Ensure params is initialized
 	if in.params == nil {
@@ -0,0 +1,296 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkInterface_UnmarshalBond(t *testing.T) {
+	t.Run("bond with params", func(t *testing.T) {
+		data := `{
+			"id": 12,
+			"name": "bond0",
+			"type": "bond",
+			"parents": ["eth0", "eth1"],
+			"params": {
+				"bond_mode": "802.3ad",
+				"bond_miimon": 100,
+				"bond_downdelay": 0,
+				"bond_updelay": 0,
+				"bond_lacp_rate": "fast",
+				"bond_xmit_hash_policy": "layer3+4"
+			}
+		}`
+
+		var iface *networkInterface
+		err := json.Unmarshal([]byte(data), &iface)
+		assert.Nil(t, err)
+		assert.Equal(t, iface.ID(), "12")
+		assert.Equal(t, iface.Parents(), []string{"eth0", "eth1"})
+		assert.NotNil(t, iface.BondParameters())
+		assert.Equal(t, iface.BondParameters().Mode, BondMode8023AD)
+		assert.Equal(t, iface.BondParameters().MIIMon, 100)
+		assert.Equal(t, iface.BondParameters().LACPRate, "fast")
+		assert.Equal(t, iface.BondParameters().XmitHashPolicy, "layer3+4")
+	})
+
+	t.Run("physical with empty params", func(t *testing.T) {
+		data := `{"id": 3, "name": "eth0", "type": "physical", "parents": [], "params": ""}`
+
+		var iface *networkInterface
+		err := json.Unmarshal([]byte(data), &iface)
+		assert.Nil(t, err)
+		assert.Empty(t, iface.Parents())
+		assert.Nil(t, iface.BondParameters())
+	})
+}
+
+func TestNetworkInterfaces_CreateBond(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a Ready machine and the ID of a second physical interface on it
+	systemID := "REPLACE_WITH_MACHINE_SYSTEM_ID"
+	secondInterfaceID := "REPLACE_WITH_INTERFACE_ID"
+
+	t.Run("bond without parents", func(t *testing.T) {
+		res, err := c.NetworkInterfaces().CreateBond(ctx, systemID, "bond0", nil, BondOptions{})
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("lacp bond on boot interface", func(t *testing.T) {
+		if systemID == "REPLACE_WITH_MACHINE_SYSTEM_ID" {
+			t.Skip("Please replace placeholder machine system ID and interface ID")
+			return
+		}
+
+		miimon := 100
+		res, err := c.NetworkInterfaces().CreateBootInterfaceBond(ctx, systemID, "bond0", []string{secondInterfaceID}, BondOptions{
+			Mode:           BondMode8023AD,
+			MIIMon:         &miimon,
+			LACPRate:       "fast",
+			XmitHashPolicy: "layer3+4",
+		})
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Type(), "bond")
+		assert.Len(t, res.Parents(), 2)
+		assert.Equal(t, res.BondParameters().Mode, BondMode8023AD)
+	})
+}
+
+func TestNetworkInterfaces_CreateVLANInterface(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a Ready machine and one of its physical interface IDs
+	systemID := "REPLACE_WITH_MACHINE_SYSTEM_ID"
+	parentInterfaceID := "REPLACE_WITH_INTERFACE_ID"
+
+	t.Run("tagged interface by vid", func(t *testing.T) {
+		if systemID == "REPLACE_WITH_MACHINE_SYSTEM_ID" {
+			t.Skip("Please replace placeholder machine system ID and interface ID")
+			return
+		}
+
+		mtu := 9000
+		res, err := c.NetworkInterfaces().CreateVLANInterfaceByVID(ctx, systemID, parentInterfaceID, "fabric-0", 100, VLANInterfaceOptions{
+			Tags: []string{"storage"},
+			MTU:  &mtu,
+		})
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Type(), "vlan")
+		assert.Equal(t, res.VLAN().VID(), 100)
+		assert.Equal(t, res.VLAN().FabricName(), "fabric-0")
+	})
+}
+
+func TestNetworkInterfaces_CreateVLANInterfaceWrongVLAN(t *testing.T) {
+	ctx := context.Background()
+	created := `{"id": 12, "name": "eth0.100", "type": "vlan", "vlan": {"id": 5002, "vid": 100}}`
+
+	t.Run("interface is removed", func(t *testing.T) {
+		client := newStubClient(map[string]stubHandler{
+			http.MethodPost: func(req stubRequest) *http.Response {
+				return stubResponse(http.StatusOK, created)
+			},
+			http.MethodDelete: func(req stubRequest) *http.Response {
+				return stubResponse(http.StatusNoContent, "")
+			},
+		})
+		ni := &networkInterfaces{Controller: Controller{client: client, params: ParamsBuilder()}}
+
+		res, err := ni.CreateVLANInterface(ctx, "abc123", "3", 5003, VLANInterfaceOptions{})
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+		deletes := client.sent(http.MethodDelete)
+		if assert.Len(t, deletes, 1) {
+			assert.Equal(t, deletes[0].path, "/nodes/abc123/interfaces/12/")
+		}
+	})
+
+	t.Run("failed removal returns the interface", func(t *testing.T) {
+		client := newStubClient(map[string]stubHandler{
+			http.MethodPost: func(req stubRequest) *http.Response {
+				return stubResponse(http.StatusOK, created)
+			},
+			http.MethodDelete: func(req stubRequest) *http.Response {
+				return stubResponse(http.StatusInternalServerError, "boom")
+			},
+		})
+		ni := &networkInterfaces{Controller: Controller{client: client, params: ParamsBuilder()}}
+
+		res, err := ni.CreateVLANInterface(ctx, "abc123", "3", 5003, VLANInterfaceOptions{})
+		assert.NotNil(t, err)
+		if assert.NotNil(t, res) {
+			assert.Equal(t, res.ID(), "12")
+		}
+	})
+}
+
+func TestNetworkInterface_UpdateAndDelete(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a device (or deployed machine) system ID
+	systemID := "REPLACE_WITH_DEVICE_SYSTEM_ID"
+
+	t.Run("physical interface without mac", func(t *testing.T) {
+		res, err := c.NetworkInterfaces().CreatePhysical(ctx, systemID, "", PhysicalInterfaceOptions{})
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("create, update, tag and delete", func(t *testing.T) {
+		if systemID == "REPLACE_WITH_DEVICE_SYSTEM_ID" {
+			t.Skip("Please replace placeholder device system ID")
+			return
+		}
+
+		iface, err := c.NetworkInterfaces().CreatePhysical(ctx, systemID, "52:54:00:12:34:56", PhysicalInterfaceOptions{
+			Name: "eth-test",
+		})
+		assert.Nil(t, err)
+		assert.NotNil(t, iface)
+
+		name := "eth-renamed"
+		mtu := 1400
+		iface, err = iface.Update(ctx, InterfaceUpdate{Name: &name, MTU: &mtu})
+		assert.Nil(t, err)
+		assert.Equal(t, iface.Name(), name)
+		assert.Equal(t, iface.EffectiveMTU(), mtu)
+
+		iface, err = iface.AddTag(ctx, "test-tag")
+		assert.Nil(t, err)
+		assert.Contains(t, iface.Tags(), "test-tag")
+
+		iface, err = iface.RemoveTag(ctx, "test-tag")
+		assert.Nil(t, err)
+		assert.NotContains(t, iface.Tags(), "test-tag")
+
+		iface, err = iface.Disconnect(ctx)
+		assert.Nil(t, err)
+		assert.Empty(t, iface.Links())
+
+		err = iface.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
+
+// newLinkStubClient answers interface link operations without a MAAS server,
+// failing link_subnet calls whose mode is in failModes
+func newLinkStubClient(failModes map[string]bool) *stubClient {
+	return newStubClient(map[string]stubHandler{
+		http.MethodPost: func(req stubRequest) *http.Response {
+			if req.params.Get(Operation) == OperationLinkSubnet && failModes[req.params.Get(ModeKey)] {
+				return stubResponse(http.StatusBadRequest, `{"ip_address": ["IP address is already in use."]}`)
+			}
+			return stubResponse(http.StatusOK, `{}`)
+		},
+	})
+}
+
+func TestNetworkInterface_UpdateIPConfigurationRollback(t *testing.T) {
+	newInterface := func(client Client) *networkInterface {
+		data := `{
+			"id": 5,
+			"name": "eth0",
+			"type": "physical",
+			"links": [{"id": 7, "mode": "dhcp", "subnet": {"id": 3, "cidr": "10.0.0.0/24"}}]
+		}`
+		var iface *networkInterface
+		err := json.Unmarshal([]byte(data), &iface)
+		assert.Nil(t, err)
+		iface.systemID = "abc123"
+		networkInterfaceStructToInterface(iface, client)
+		return iface
+	}
+
+	t.Run("restores previous link", func(t *testing.T) {
+		client := newLinkStubClient(map[string]bool{ModeStatic: true})
+		iface := newInterface(client)
+
+		err := iface.SetStaticIP(context.Background(), "10.0.0.5")
+
+		var ipErr *IPConfigurationError
+		assert.True(t, errors.As(err, &ipErr))
+		assert.True(t, ipErr.RolledBack())
+		assert.Contains(t, err.Error(), "already in use")
+
+		posts := client.sent(http.MethodPost)
+		assert.Len(t, posts, 3)
+		assert.Equal(t, posts[0].params.Get(Operation), OperationUnlinkSubnet)
+		assert.Equal(t, posts[0].params.Get(LinkIDKey), "7")
+		assert.Equal(t, posts[1].params.Get(IPAddressKey), "10.0.0.5")
+		assert.Equal(t, posts[2].params.Get(ModeKey), ModeDHCP)
+		assert.Equal(t, posts[2].params.Get(SubnetKey), "3")
+	})
+
+	t.Run("reports failed rollback", func(t *testing.T) {
+		client := newLinkStubClient(map[string]bool{ModeStatic: true, ModeDHCP: true})
+		iface := newInterface(client)
+
+		err := iface.SetStaticIP(context.Background(), "10.0.0.5")
+
+		var ipErr *IPConfigurationError
+		assert.True(t, errors.As(err, &ipErr))
+		assert.False(t, ipErr.RolledBack())
+		assert.Contains(t, err.Error(), "rollback of previous configuration failed")
+	})
+
+	t.Run("no rollback on success", func(t *testing.T) {
+		client := newLinkStubClient(nil)
+		iface := newInterface(client)
+
+		err := iface.SetStaticIP(context.Background(), "10.0.0.5")
+		assert.Nil(t, err)
+		assert.Len(t, client.sent(http.MethodPost), 2)
+	})
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package maasclient
 
-import "net/url"
+import (
+	"net/url"
+	"strconv"
+)
 
 type params struct {
 	values url.Values
@@ -50,6 +53,13 @@ func (p *params) Copy(in Params) {
 	}
 }
 
+// addIntParams adds each id under key; MAAS reads repeated keys as a list
+func addIntParams(p Params, key string, ids []int) {
+	for _, id := range ids {
+		p.Add(key, strconv.Itoa(id))
+	}
+}
+
 type Params interface {
 	Add(key, value string) Params
 	Set(key, value string) Params
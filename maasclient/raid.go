@@ -0,0 +1,361 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	RAIDsAPIPathFormat = "/nodes/%s/raids/"
+	RAIDAPIPathFormat  = "/nodes/%s/raid/%d/"
+)
+
+// RAIDs manages the software RAID (MD) devices of a machine
+type RAIDs interface {
+	List(ctx context.Context) ([]RAID, error)
+	RAID(id int) RAID
+	Builder() RAIDBuilder
+}
+
+// RAID represents a single software RAID device on a machine
+type RAID interface {
+	Get(ctx context.Context) (RAID, error)
+	Delete(ctx context.Context) error
+	Modifier() RAIDModifier
+	ID() int
+	UUID() string
+	Name() string
+	// Level returns the RAID level, one of the RAIDLevel* constants
+	Level() string
+	// Size returns the usable size of the RAID in bytes
+	Size() int64
+	SystemID() string
+	// Devices returns the active member block devices and partitions
+	Devices() []BlockDevice
+	// SpareDevices returns the spare block devices and partitions
+	SpareDevices() []BlockDevice
+	// VirtualDevice returns the block device MAAS exposes for the assembled RAID
+	VirtualDevice() BlockDevice
+}
+
+type RAIDBuilder interface {
+	WithName(name string) RAIDBuilder
+	WithUUID(uuid string) RAIDBuilder
+	// WithLevel sets the RAID level, one of the RAIDLevel* constants
+	WithLevel(level string) RAIDBuilder
+	WithBlockDevices(ids []int) RAIDBuilder
+	WithPartitions(ids []int) RAIDBuilder
+	WithSpareDevices(ids []int) RAIDBuilder
+	WithSparePartitions(ids []int) RAIDBuilder
+	Create(ctx context.Context) (RAID, error)
+}
+
+type RAIDModifier interface {
+	SetName(name string) RAIDModifier
+	SetUUID(uuid string) RAIDModifier
+	AddBlockDevices(ids []int) RAIDModifier
+	RemoveBlockDevices(ids []int) RAIDModifier
+	AddPartitions(ids []int) RAIDModifier
+	RemovePartitions(ids []int) RAIDModifier
+	AddSpareDevices(ids []int) RAIDModifier
+	RemoveSpareDevices(ids []int) RAIDModifier
+	AddSparePartitions(ids []int) RAIDModifier
+	RemoveSparePartitions(ids []int) RAIDModifier
+	Update(ctx context.Context) (RAID, error)
+}
+
+type raids struct {
+	Controller
+	systemID string
+}
+
+func (rs *raids) List(ctx context.Context) ([]RAID, error) {
+	res, err := rs.client.Get(ctx, rs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*raid
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return raidStructSliceToInterface(obj, rs.client), nil
+}
+
+func (rs *raids) RAID(id int) RAID {
+	return raidStructToInterface(&raid{id: id, systemID: rs.systemID}, rs.client)
+}
+
+func (rs *raids) Builder() RAIDBuilder {
+	rs.params.Reset()
+	return rs
+}
+
+func (rs *raids) WithName(name string) RAIDBuilder {
+	rs.params.Set(NameKey, name)
+	return rs
+}
+
+func (rs *raids) WithUUID(uuid string) RAIDBuilder {
+	rs.params.Set(UUIDKey, uuid)
+	return rs
+}
+
+func (rs *raids) WithLevel(level string) RAIDBuilder {
+	rs.params.Set(LevelKey, level)
+	return rs
+}
+
+func (rs *raids) WithBlockDevices(ids []int) RAIDBuilder {
+	addIntParams(rs.params, BlockDevicesKey, ids)
+	return rs
+}
+
+func (rs *raids) WithPartitions(ids []int) RAIDBuilder {
+	addIntParams(rs.params, PartitionsKey, ids)
+	return rs
+}
+
+func (rs *raids) WithSpareDevices(ids []int) RAIDBuilder {
+	addIntParams(rs.params, SpareDevicesKey, ids)
+	return rs
+}
+
+func (rs *raids) WithSparePartitions(ids []int) RAIDBuilder {
+	addIntParams(rs.params, SparePartitionsKey, ids)
+	return rs
+}
+
+func (rs *raids) Create(ctx context.Context) (RAID, error) {
+	if !isValidRAIDLevel(rs.params.Values().Get(LevelKey)) {
+		return nil, fmt.Errorf("invalid RAID level %q", rs.params.Values().Get(LevelKey))
+	}
+
+	res, err := rs.client.Post(ctx, rs.apiPath, rs.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *raid
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return raidStructToInterface(obj, rs.client), nil
+}
+
+func isValidRAIDLevel(level string) bool {
+	switch level {
+	case RAIDLevel0, RAIDLevel1, RAIDLevel5, RAIDLevel6, RAIDLevel10:
+		return true
+	}
+	return false
+}
+
+func raidStructSliceToInterface(in []*raid, client Client) []RAID {
+	var out []RAID
+	for _, r := range in {
+		out = append(out, raidStructToInterface(r, client))
+	}
+	return out
+}
+
+func raidStructToInterface(in *raid, client Client) RAID {
+	in.client = client
+	in.apiPath = fmt.Sprintf(RAIDAPIPathFormat, in.systemID, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type raid struct {
+	Controller
+	id            int
+	uuid          string
+	name          string
+	level         string
+	size          int64
+	systemID      string
+	devices       []*blockDevice
+	spareDevices  []*blockDevice
+	virtualDevice *blockDevice
+}
+
+func (r *raid) Get(ctx context.Context) (RAID, error) {
+	res, err := r.client.Get(ctx, r.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *raid) Delete(ctx context.Context) error {
+	res, err := r.client.Delete(ctx, r.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (r *raid) Modifier() RAIDModifier {
+	r.params.Reset()
+	return r
+}
+
+func (r *raid) SetName(name string) RAIDModifier {
+	r.params.Set(NameKey, name)
+	return r
+}
+
+func (r *raid) SetUUID(uuid string) RAIDModifier {
+	r.params.Set(UUIDKey, uuid)
+	return r
+}
+
+func (r *raid) AddBlockDevices(ids []int) RAIDModifier {
+	addIntParams(r.params, AddBlockDevicesKey, ids)
+	return r
+}
+
+func (r *raid) RemoveBlockDevices(ids []int) RAIDModifier {
+	addIntParams(r.params, RemoveBlockDevicesKey, ids)
+	return r
+}
+
+func (r *raid) AddPartitions(ids []int) RAIDModifier {
+	addIntParams(r.params, AddPartitionsKey, ids)
+	return r
+}
+
+func (r *raid) RemovePartitions(ids []int) RAIDModifier {
+	addIntParams(r.params, RemovePartitionsKey, ids)
+	return r
+}
+
+func (r *raid) AddSpareDevices(ids []int) RAIDModifier {
+	addIntParams(r.params, AddSpareDevicesKey, ids)
+	return r
+}
+
+func (r *raid) RemoveSpareDevices(ids []int) RAIDModifier {
+	addIntParams(r.params, RemoveSpareDevicesKey, ids)
+	return r
+}
+
+func (r *raid) AddSparePartitions(ids []int) RAIDModifier {
+	addIntParams(r.params, AddSparePartitionsKey, ids)
+	return r
+}
+
+func (r *raid) RemoveSparePartitions(ids []int) RAIDModifier {
+	addIntParams(r.params, RemoveSparePartitionsKey, ids)
+	return r
+}
+
+func (r *raid) Update(ctx context.Context) (RAID, error) {
+	res, err := r.client.PutParams(ctx, r.apiPath, r.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *raid) ID() int {
+	return r.id
+}
+
+func (r *raid) UUID() string {
+	return r.uuid
+}
+
+func (r *raid) Name() string {
+	return r.name
+}
+
+func (r *raid) Level() string {
+	return r.level
+}
+
+func (r *raid) Size() int64 {
+	return r.size
+}
+
+func (r *raid) SystemID() string {
+	return r.systemID
+}
+
+func (r *raid) Devices() []BlockDevice {
+	return blockDeviceSliceToInterface(r.devices)
+}
+
+func (r *raid) SpareDevices() []BlockDevice {
+	return blockDeviceSliceToInterface(r.spareDevices)
+}
+
+func (r *raid) VirtualDevice() BlockDevice {
+	return blockDeviceToInterface(r.virtualDevice)
+}
+
+func (r *raid) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID            int            `json:"id"`
+		UUID          string         `json:"uuid"`
+		Name          string         `json:"name"`
+		Level         string         `json:"level"`
+		Size          int64          `json:"size"`
+		SystemID      string         `json:"system_id"`
+		Devices       []*blockDevice `json:"devices"`
+		SpareDevices  []*blockDevice `json:"spare_devices"`
+		VirtualDevice *blockDevice   `json:"virtual_device"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	r.id = des.ID
+	r.uuid = des.UUID
+	r.name = des.Name
+	r.level = des.Level
+	r.size = des.Size
+	r.systemID = des.SystemID
+	r.devices = des.Devices
+	r.spareDevices = des.SpareDevices
+	r.virtualDevice = des.VirtualDevice
+
+	return nil
+}
+
+func newRAIDsClient(client Client, systemID string) RAIDs {
+	return &raids{
+		Controller: Controller{
+			client:  client,
+			apiPath: fmt.Sprintf(RAIDsAPIPathFormat, systemID),
+			params:  ParamsBuilder(),
+		},
+		systemID: systemID,
+	}
+}
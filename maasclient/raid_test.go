@@ -0,0 +1,76 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRAIDs(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a Ready machine that has at least three spare disks
+	systemID := "REPLACE_WITH_MACHINE_SYSTEM_ID"
+	blockDevices := []int{0, 0}
+	spareDevices := []int{0}
+
+	t.Run("invalid level", func(t *testing.T) {
+		res, err := c.Machines().Machine(systemID).RAIDs().
+			Builder().
+			WithLevel("raid-4").
+			Create(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("create, update and delete raid", func(t *testing.T) {
+		if systemID == "REPLACE_WITH_MACHINE_SYSTEM_ID" {
+			t.Skip("Please replace placeholder machine system ID and block device IDs")
+			return
+		}
+
+		raids := c.Machines().Machine(systemID).RAIDs()
+
+		res, err := raids.Builder().
+			WithName("md-test").
+			WithLevel(RAIDLevel1).
+			WithBlockDevices(blockDevices).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Level(), RAIDLevel1)
+		assert.Len(t, res.Devices(), len(blockDevices))
+		assert.NotNil(t, res.VirtualDevice())
+
+		res, err = res.Modifier().AddSpareDevices(spareDevices).Update(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, res.SpareDevices(), len(spareDevices))
+
+		list, err := raids.List(ctx)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, list)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
@@ -20,22 +20,55 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 const (
 	ResourcePoolsAPIPath      = "/resourcepools/"
-	ResourcePoolAPIPathFormat = "/resourcepools/%d"
+	ResourcePoolAPIPathFormat = "/resourcepools/%d/"
 )
 
 type ResourcePools interface {
 	List(ctx context.Context, params Params) ([]ResourcePool, error)
 	ResourcePool(id int) ResourcePool
+	Builder() ResourcePoolBuilder
 }
 
 type ResourcePool interface {
+	Get(ctx context.Context) (ResourcePool, error)
+	Delete(ctx context.Context) error
+	Modifier() ResourcePoolModifier
 	Name() string
 	Description() string
 	ID() int
+	// AddMachine moves the machine with the given system ID into the pool
+	AddMachine(ctx context.Context, systemID string) error
+	// AddMachines moves each of the given machines into the pool, carrying on past
+	// failures. The results are in the order of systemIDs.
+	AddMachines(ctx context.Context, systemIDs []string) ([]MachinePoolResult, error)
+	// MachineCount returns the number of machines in the pool
+	MachineCount(ctx context.Context) (int, error)
+	// VMHostCount returns the number of VM hosts in the pool
+	VMHostCount(ctx context.Context) (int, error)
+}
+
+type ResourcePoolBuilder interface {
+	WithName(name string) ResourcePoolBuilder
+	WithDescription(description string) ResourcePoolBuilder
+	Create(ctx context.Context) (ResourcePool, error)
+}
+
+type ResourcePoolModifier interface {
+	SetName(name string) ResourcePoolModifier
+	SetDescription(description string) ResourcePoolModifier
+	Update(ctx context.Context) (ResourcePool, error)
+}
+
+// MachinePoolResult is the outcome of moving a single machine into a resource pool
+type MachinePoolResult struct {
+	SystemID string
+	// Err is nil when the machine was moved
+	Err error
 }
 
 type resourcePools struct {
@@ -43,7 +76,12 @@ type resourcePools struct {
 }
 
 func (rps *resourcePools) List(ctx context.Context, params Params) ([]ResourcePool, error) {
-	res, err := rps.client.Get(ctx, rps.apiPath, rps.params.Values())
+	var values url.Values
+	if params != nil {
+		values = params.Values()
+	}
+
+	res, err := rps.client.Get(ctx, rps.apiPath, values)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +106,7 @@ func resourcePoolStructSliceToInterface(in []*resourcePool, client Client) []Res
 func resourcePoolStructToInterface(in *resourcePool, client Client) ResourcePool {
 	in.client = client
 	in.apiPath = fmt.Sprintf(ResourcePoolAPIPathFormat, in.ID())
+	in.params = ParamsBuilder()
 	return in
 }
 
@@ -75,6 +114,36 @@ func (rps *resourcePools) ResourcePool(id int) ResourcePool {
 	return resourcePoolStructToInterface(&resourcePool{id: id}, rps.client)
 }
 
+func (rps *resourcePools) Builder() ResourcePoolBuilder {
+	rps.params.Reset()
+	return rps
+}
+
+func (rps *resourcePools) WithName(name string) ResourcePoolBuilder {
+	rps.params.Set(NameKey, name)
+	return rps
+}
+
+func (rps *resourcePools) WithDescription(description string) ResourcePoolBuilder {
+	rps.params.Set(DescriptionKey, description)
+	return rps
+}
+
+func (rps *resourcePools) Create(ctx context.Context) (ResourcePool, error) {
+	res, err := rps.client.Post(ctx, rps.apiPath, rps.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *resourcePool
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourcePoolStructToInterface(obj, rps.client), nil
+}
+
 type resourcePool struct {
 	name        string
 	id          int
@@ -83,6 +152,137 @@ type resourcePool struct {
 	Controller
 }
 
+func (rp *resourcePool) Get(ctx context.Context) (ResourcePool, error) {
+	res, err := rp.client.Get(ctx, rp.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return rp, unMarshalJson(res, &rp)
+}
+
+func (rp *resourcePool) Delete(ctx context.Context) error {
+	res, err := rp.client.Delete(ctx, rp.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (rp *resourcePool) Modifier() ResourcePoolModifier {
+	rp.params.Reset()
+	return rp
+}
+
+func (rp *resourcePool) SetName(name string) ResourcePoolModifier {
+	rp.params.Set(NameKey, name)
+	return rp
+}
+
+func (rp *resourcePool) SetDescription(description string) ResourcePoolModifier {
+	rp.params.Set(DescriptionKey, description)
+	return rp
+}
+
+func (rp *resourcePool) Update(ctx context.Context) (ResourcePool, error) {
+	res, err := rp.client.PutParams(ctx, rp.apiPath, rp.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return rp, unMarshalJson(res, &rp)
+}
+
+func (rp *resourcePool) AddMachine(ctx context.Context, systemID string) error {
+	results, err := rp.AddMachines(ctx, []string{systemID})
+	if err != nil {
+		return err
+	}
+
+	return results[0].Err
+}
+
+func (rp *resourcePool) AddMachines(ctx context.Context, systemIDs []string) ([]MachinePoolResult, error) {
+	name, err := rp.poolName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]MachinePoolResult, 0, len(systemIDs))
+	for _, systemID := range systemIDs {
+		params := url.Values{}
+		params.Set(PoolLabel, name)
+
+		res, err := rp.client.PutParams(ctx, fmt.Sprintf("/machines/%s/", systemID), params)
+		if err == nil {
+			err = unMarshalJson(res, nil)
+		}
+		results = append(results, MachinePoolResult{SystemID: systemID, Err: err})
+	}
+
+	return results, nil
+}
+
+func (rp *resourcePool) MachineCount(ctx context.Context) (int, error) {
+	name, err := rp.poolName(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	params := url.Values{}
+	params.Set(PoolLabel, name)
+
+	res, err := rp.client.Get(ctx, "/machines/", params)
+	if err != nil {
+		return 0, err
+	}
+
+	var machines []json.RawMessage
+	err = unMarshalJson(res, &machines)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(machines), nil
+}
+
+func (rp *resourcePool) VMHostCount(ctx context.Context) (int, error) {
+	res, err := rp.client.Get(ctx, "/vm-hosts/", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var hosts []struct {
+		Pool struct {
+			ID int `json:"id"`
+		} `json:"pool"`
+	}
+	err = unMarshalJson(res, &hosts)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, host := range hosts {
+		if host.Pool.ID == rp.id {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// poolName returns the name of the pool, fetching it for handles created by ID
+func (rp *resourcePool) poolName(ctx context.Context) (string, error) {
+	if rp.name == "" {
+		if _, err := rp.Get(ctx); err != nil {
+			return "", err
+		}
+	}
+	return rp.name, nil
+}
+
 func (rp *resourcePool) UnmarshalJSON(data []byte) error {
 	des := &struct {
 		Id          int    `json:"id"`
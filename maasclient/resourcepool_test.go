@@ -19,7 +19,9 @@ package maasclient
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -34,4 +36,64 @@ func TestResourcePool(t *testing.T) {
 		assert.NotNil(t, res)
 		assert.NotEmpty(t, res)
 	})
+
+	t.Run("create, update and delete resourcepool", func(t *testing.T) {
+		res, err := c.ResourcePools().Builder().
+			WithName("pool-test").
+			WithDescription("created by tests").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Name(), "pool-test")
+
+		res, err = res.Modifier().SetDescription("updated").Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Description(), "updated")
+
+		res, err = c.ResourcePools().ResourcePool(res.ID()).Get(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Name(), "pool-test")
+
+		count, err := res.MachineCount(ctx)
+		assert.Nil(t, err)
+		assert.Zero(t, count)
+
+		count, err = res.VMHostCount(ctx)
+		assert.Nil(t, err)
+		assert.Zero(t, count)
+
+		results, err := res.AddMachines(ctx, []string{"doesnotexist"})
+		assert.Nil(t, err)
+		assert.NotNil(t, results[0].Err)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
+
+func TestResourcePool_AddMachines(t *testing.T) {
+	client := newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 2, "name": "gpu", "description": ""}`)
+		},
+		http.MethodPut: func(req stubRequest) *http.Response {
+			if strings.Split(req.path, "/")[2] == "bbb222" {
+				return stubResponse(http.StatusForbidden, `You do not have permission to edit this machine.`)
+			}
+			return stubResponse(http.StatusOK, `{}`)
+		},
+	})
+	pool := resourcePoolStructToInterface(&resourcePool{id: 2}, client)
+
+	results, err := pool.AddMachines(context.Background(), []string{"aaa111", "bbb222", "ccc333"})
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, results[0].SystemID, "aaa111")
+	assert.Nil(t, results[0].Err)
+	assert.NotNil(t, results[1].Err)
+	assert.Nil(t, results[2].Err)
+	puts := client.sent(http.MethodPut)
+	assert.Len(t, puts, 3)
+	assert.Equal(t, puts[2].path, "/machines/ccc333/")
+	assert.Equal(t, puts[2].params.Get(PoolLabel), "gpu")
 }
@@ -19,34 +19,126 @@ package maasclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	SpacesAPIPath      = "/spaces/"
+	SpaceAPIPathFormat = "/spaces/%d/"
 )
 
 type Spaces interface {
 	List(ctx context.Context) ([]Space, error)
+	Space(id int) Space
+	Builder() SpaceBuilder
+	// AssignVLAN moves the VLAN with the given fabric ID and VID into the space with the given ID
+	AssignVLAN(ctx context.Context, fabricID, vid, spaceID int) (VLAN, error)
+	// UnassignVLAN removes the VLAN with the given fabric ID and VID from its space
+	UnassignVLAN(ctx context.Context, fabricID, vid int) (VLAN, error)
 }
 
 type Space interface {
+	Get(ctx context.Context) (Space, error)
+	Delete(ctx context.Context) error
+	Modifier() SpaceModifier
+	ID() int
 	Name() string
+	Description() string
 	Subnets() []Subnet
+	VLANs() []VLAN
+}
+
+type SpaceBuilder interface {
+	WithName(name string) SpaceBuilder
+	WithDescription(description string) SpaceBuilder
+	Create(ctx context.Context) (Space, error)
+}
+
+type SpaceModifier interface {
+	SetName(name string) SpaceModifier
+	SetDescription(description string) SpaceModifier
+	Update(ctx context.Context) (Space, error)
 }
 
 type space struct {
-	name    string
-	subnets []*subnet
+	Controller
+	id          int
+	name        string
+	description string
+	subnets     []*subnet
+	vlans       []*vlan
+}
+
+func (s *space) Get(ctx context.Context) (Space, error) {
+	res, err := s.client.Get(ctx, s.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, unMarshalJson(res, &s)
+}
+
+func (s *space) Delete(ctx context.Context) error {
+	res, err := s.client.Delete(ctx, s.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (s *space) Modifier() SpaceModifier {
+	s.params.Reset()
+	return s
+}
+
+func (s *space) SetName(name string) SpaceModifier {
+	s.params.Set(NameKey, name)
+	return s
+}
+
+func (s *space) SetDescription(description string) SpaceModifier {
+	s.params.Set(DescriptionKey, description)
+	return s
+}
+
+func (s *space) Update(ctx context.Context) (Space, error) {
+	res, err := s.client.PutParams(ctx, s.apiPath, s.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return s, unMarshalJson(res, &s)
+}
+
+func (s *space) ID() int {
+	return s.id
 }
 
 func (s *space) Name() string {
 	return s.name
 }
 
+func (s *space) Description() string {
+	return s.description
+}
+
 func (s *space) Subnets() []Subnet {
-	return subnetSliceToInterface(s.subnets)
+	return subnetStructSliceToInterface(s.subnets, s.client)
+}
+
+func (s *space) VLANs() []VLAN {
+	return vlanStructSliceToInterface(s.vlans, s.client)
 }
 
 func (s *space) UnmarshalJSON(data []byte) error {
 	des := &struct {
-		Name    string    `json:"name"`
-		Subnets []*subnet `json:"subnets"`
+		ID          int       `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Subnets     []*subnet `json:"subnets"`
+		VLANs       []*vlan   `json:"vlans"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -54,8 +146,11 @@ func (s *space) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	s.id = des.ID
 	s.name = des.Name
+	s.description = des.Description
 	s.subnets = des.Subnets
+	s.vlans = des.VLANs
 
 	return nil
 }
@@ -65,7 +160,7 @@ type spaces struct {
 }
 
 func (ss *spaces) List(ctx context.Context) ([]Space, error) {
-	res, err := ss.client.Get(ctx, ss.apiPath, ss.params.Values())
+	res, err := ss.client.Get(ctx, ss.apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +174,48 @@ func (ss *spaces) List(ctx context.Context) ([]Space, error) {
 	return spaceStructSliceToInterface(obj, ss.client), nil
 }
 
+func (ss *spaces) Space(id int) Space {
+	return spaceStructToInterface(&space{id: id}, ss.client)
+}
+
+func (ss *spaces) Builder() SpaceBuilder {
+	ss.params.Reset()
+	return ss
+}
+
+func (ss *spaces) WithName(name string) SpaceBuilder {
+	ss.params.Set(NameKey, name)
+	return ss
+}
+
+func (ss *spaces) WithDescription(description string) SpaceBuilder {
+	ss.params.Set(DescriptionKey, description)
+	return ss
+}
+
+func (ss *spaces) Create(ctx context.Context) (Space, error) {
+	res, err := ss.client.Post(ctx, ss.apiPath, ss.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *space
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return spaceStructToInterface(obj, ss.client), nil
+}
+
+func (ss *spaces) AssignVLAN(ctx context.Context, fabricID, vid, spaceID int) (VLAN, error) {
+	return NewVLANsClient(ss.client, fabricID).VLAN(vid).Modifier().SetSpace(strconv.Itoa(spaceID)).Update(ctx)
+}
+
+func (ss *spaces) UnassignVLAN(ctx context.Context, fabricID, vid int) (VLAN, error) {
+	return NewVLANsClient(ss.client, fabricID).VLAN(vid).Modifier().SetSpace("").Update(ctx)
+}
+
 func spaceStructSliceToInterface(in []*space, client Client) []Space {
 	var out []Space
 	for _, s := range in {
@@ -88,6 +225,9 @@ func spaceStructSliceToInterface(in []*space, client Client) []Space {
 }
 
 func spaceStructToInterface(in *space, client Client) Space {
+	in.client = client
+	in.apiPath = fmt.Sprintf(SpaceAPIPathFormat, in.id)
+	in.params = ParamsBuilder()
 	return in
 }
 
@@ -95,7 +235,7 @@ func NewSpacesClient(client *authenticatedClient) Spaces {
 	return &spaces{
 		Controller: Controller{
 			client:  client,
-			apiPath: "/spaces/",
+			apiPath: SpacesAPIPath,
 			params:  ParamsBuilder(),
 		},
 	}
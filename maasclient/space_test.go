@@ -34,4 +34,48 @@ func TestSpaces(t *testing.T) {
 		assert.NotNil(t, res)
 		assert.NotEmpty(t, res)
 	})
+
+	t.Run("create, update and delete space", func(t *testing.T) {
+		res, err := c.Spaces().Builder().
+			WithName("space-test").
+			WithDescription("created by tests").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Name(), "space-test")
+
+		res, err = res.Modifier().SetName("space-renamed").Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Name(), "space-renamed")
+
+		res, err = c.Spaces().Space(res.ID()).Get(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Description(), "created by tests")
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+
+	t.Run("move vlan into and out of space", func(t *testing.T) {
+		sp, err := c.Spaces().Builder().WithName("space-vlan-test").Create(ctx)
+		assert.Nil(t, err)
+
+		_, err = c.VLANs(0).Builder().WithVID(3998).Create(ctx)
+		assert.Nil(t, err)
+
+		v, err := c.Spaces().AssignVLAN(ctx, 0, 3998, sp.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, v.Space(), "space-vlan-test")
+
+		sp, err = sp.Get(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, sp.VLANs(), 1)
+
+		v, err = c.Spaces().UnassignVLAN(ctx, 0, 3998)
+		assert.Nil(t, err)
+		assert.Equal(t, v.Space(), "undefined")
+
+		assert.Nil(t, c.VLANs(0).VLAN(3998).Delete(ctx))
+		assert.Nil(t, sp.Delete(ctx))
+	})
 }
@@ -17,24 +17,102 @@ limitations under the License.
 package maasclient
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
 )
 
 const (
-	SSHKeysAPIPath = "/account/prefs/sshkeys/"
+	SSHKeysAPIPath      = "/account/prefs/sshkeys/"
+	SSHKeyAPIPathFormat = "/account/prefs/sshkeys/%d/"
 )
 
+// SSHKeys manages the SSH keys of the authenticated user
 type SSHKeys interface {
 	List(ctx context.Context) ([]SSHKey, error)
+	// Add validates the public key locally and adds it
+	Add(ctx context.Context, key string) (SSHKey, error)
+	// AddForUser adds the public key to another user's account, this requires an administrator
+	AddForUser(ctx context.Context, username, key string) (SSHKey, error)
+	// Delete deletes a key, MAAS only allows deleting keys of the authenticated user
+	Delete(ctx context.Context, id int) error
+	// Import imports the keys of a Launchpad or GitHub account, keySource is either
+	// "lp:<user>" or "gh:<user>". MAAS has no admin variant, keys are always imported
+	// for the authenticated user.
+	Import(ctx context.Context, keySource string) ([]SSHKey, error)
 }
 
 type SSHKey interface {
 	ID() int
 	Key() string
+	// KeySource returns the source the key was imported from as "lp:<user>" or "gh:<user>",
+	// or an empty string if the key was added directly
 	KeySource() string
 }
 
+// sshKeyTypes are the public key algorithms MAAS accepts
+var sshKeyTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-dss":                            true,
+	"ssh-ed25519":                        true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+	"sk-ssh-ed25519@openssh.com":         true,
+}
+
+// validatePublicKey checks key is a single public key in authorized_keys format:
+// the key type, the base64 encoded key and an optional comment
+func validatePublicKey(key string) error {
+	key = strings.TrimSpace(key)
+	if strings.Contains(key, "PRIVATE KEY") {
+		return fmt.Errorf("invalid SSH public key: a private key was given")
+	}
+	if strings.ContainsAny(key, "\r\n") {
+		return fmt.Errorf("invalid SSH public key: expected a single key")
+	}
+
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return fmt.Errorf("invalid SSH public key: expected \"<type> <base64 key> [comment]\"")
+	}
+	keyType := fields[0]
+	if !sshKeyTypes[keyType] {
+		return fmt.Errorf("invalid SSH public key: unsupported key type %q", keyType)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid SSH public key: %w", err)
+	}
+
+	// The key blob starts with the length prefixed key type
+	if len(blob) < 4 {
+		return fmt.Errorf("invalid SSH public key: key data too short")
+	}
+	length := binary.BigEndian.Uint32(blob)
+	if uint64(len(blob)) < 4+uint64(length) || !bytes.Equal(blob[4:4+length], []byte(keyType)) {
+		return fmt.Errorf("invalid SSH public key: key data doesn't match type %s", keyType)
+	}
+
+	return nil
+}
+
+// validateKeySource checks keySource is "lp:<user>" or "gh:<user>"
+func validateKeySource(keySource string) error {
+	protocol, authID, ok := strings.Cut(keySource, ":")
+	if !ok || (protocol != KeySourceLaunchpad && protocol != KeySourceGitHub) || strings.TrimSpace(authID) == "" {
+		return fmt.Errorf("invalid key source %q, expected %s:<user> or %s:<user>", keySource, KeySourceLaunchpad, KeySourceGitHub)
+	}
+	return nil
+}
+
 type sshKeys struct {
 	Controller
 }
@@ -54,6 +132,74 @@ func (s *sshKeys) List(ctx context.Context) ([]SSHKey, error) {
 	return sshKeyStructSliceToInterface(out, s.client), err
 }
 
+func (s *sshKeys) Add(ctx context.Context, key string) (SSHKey, error) {
+	return s.add(ctx, "", key)
+}
+
+func (s *sshKeys) AddForUser(ctx context.Context, username, key string) (SSHKey, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username is required to add a key for another user")
+	}
+	return s.add(ctx, username, key)
+}
+
+func (s *sshKeys) add(ctx context.Context, username, key string) (SSHKey, error) {
+	if err := validatePublicKey(key); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set(KeyKey, strings.TrimSpace(key))
+	if username != "" {
+		params.Set(UserKey, username)
+	}
+
+	res, err := s.client.Post(ctx, s.apiPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *sshKey
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return sshKeyStructToInterface(obj, s.client), nil
+}
+
+func (s *sshKeys) Delete(ctx context.Context, id int) error {
+	res, err := s.client.Delete(ctx, fmt.Sprintf(SSHKeyAPIPathFormat, id), nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (s *sshKeys) Import(ctx context.Context, keySource string) ([]SSHKey, error) {
+	if err := validateKeySource(keySource); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set(Operation, OperationImportSSHKeys)
+	params.Set(KeySourceKey, keySource)
+
+	res, err := s.client.Post(ctx, s.apiPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*sshKey
+	err = unMarshalJson(res, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return sshKeyStructSliceToInterface(out, s.client), nil
+}
+
 func sshKeyStructSliceToInterface(in []*sshKey, client Client) []SSHKey {
 	var out []SSHKey
 	for _, s := range in {
@@ -86,9 +232,9 @@ func (s *sshKey) KeySource() string {
 
 func (s *sshKey) UnmarshalJSON(data []byte) error {
 	des := &struct {
-		ID        int    `json:"id"`
-		Key       string `json:"key"`
-		KeySource string `json:"keySource"`
+		ID        int             `json:"id"`
+		Key       string          `json:"key"`
+		KeySource json.RawMessage `json:"keysource"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -98,7 +244,23 @@ func (s *sshKey) UnmarshalJSON(data []byte) error {
 
 	s.id = des.ID
 	s.key = des.Key
-	s.keySource = des.KeySource
+
+	// Depending on the MAAS version keysource is null, "lp:user" or {"protocol", "auth_id"}
+	s.keySource = ""
+	if len(des.KeySource) > 0 && string(des.KeySource) != "null" {
+		if des.KeySource[0] == '{' {
+			source := &struct {
+				Protocol string `json:"protocol"`
+				AuthID   string `json:"auth_id"`
+			}{}
+			if err := json.Unmarshal(des.KeySource, source); err != nil {
+				return err
+			}
+			s.keySource = source.Protocol + ":" + source.AuthID
+		} else if err := json.Unmarshal(des.KeySource, &s.keySource); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
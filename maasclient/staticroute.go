@@ -0,0 +1,277 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+const (
+	StaticRoutesAPIPath      = "/static-routes/"
+	StaticRouteAPIPathFormat = "/static-routes/%d/"
+)
+
+type StaticRoutes interface {
+	List(ctx context.Context) ([]StaticRoute, error)
+	StaticRoute(id int) StaticRoute
+	Builder() StaticRouteBuilder
+}
+
+// StaticRoute is a route from a source subnet to a destination subnet, configured on
+// machines deployed with an interface on the source subnet
+type StaticRoute interface {
+	Get(ctx context.Context) (StaticRoute, error)
+	Delete(ctx context.Context) error
+	Modifier() StaticRouteModifier
+	ID() int
+	Source() Subnet
+	Destination() Subnet
+	GatewayIP() net.IP
+	Metric() int
+}
+
+type StaticRouteBuilder interface {
+	WithSource(subnetID int) StaticRouteBuilder
+	WithDestination(subnetID int) StaticRouteBuilder
+	// WithGatewayIP sets the next hop, which must be an address in the source subnet
+	WithGatewayIP(ip string) StaticRouteBuilder
+	WithMetric(metric int) StaticRouteBuilder
+	Create(ctx context.Context) (StaticRoute, error)
+}
+
+type StaticRouteModifier interface {
+	SetSource(subnetID int) StaticRouteModifier
+	SetDestination(subnetID int) StaticRouteModifier
+	SetGatewayIP(ip string) StaticRouteModifier
+	SetMetric(metric int) StaticRouteModifier
+	Update(ctx context.Context) (StaticRoute, error)
+}
+
+type staticRoutes struct {
+	Controller
+}
+
+func (rs *staticRoutes) List(ctx context.Context) ([]StaticRoute, error) {
+	res, err := rs.client.Get(ctx, rs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*staticRoute
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return staticRouteStructSliceToInterface(obj, rs.client), nil
+}
+
+func (rs *staticRoutes) StaticRoute(id int) StaticRoute {
+	return staticRouteStructToInterface(&staticRoute{id: id}, rs.client)
+}
+
+func (rs *staticRoutes) Builder() StaticRouteBuilder {
+	rs.params.Reset()
+	return rs
+}
+
+func (rs *staticRoutes) WithSource(subnetID int) StaticRouteBuilder {
+	rs.params.Set(SourceKey, strconv.Itoa(subnetID))
+	return rs
+}
+
+func (rs *staticRoutes) WithDestination(subnetID int) StaticRouteBuilder {
+	rs.params.Set(DestinationKey, strconv.Itoa(subnetID))
+	return rs
+}
+
+func (rs *staticRoutes) WithGatewayIP(ip string) StaticRouteBuilder {
+	rs.params.Set(GatewayIPKey, ip)
+	return rs
+}
+
+func (rs *staticRoutes) WithMetric(metric int) StaticRouteBuilder {
+	rs.params.Set(MetricKey, strconv.Itoa(metric))
+	return rs
+}
+
+func (rs *staticRoutes) Create(ctx context.Context) (StaticRoute, error) {
+	values := rs.params.Values()
+	if values.Get(SourceKey) == "" || values.Get(DestinationKey) == "" {
+		return nil, fmt.Errorf("static route requires a source and a destination subnet")
+	}
+	if net.ParseIP(values.Get(GatewayIPKey)) == nil {
+		return nil, fmt.Errorf("invalid gateway IP %q", values.Get(GatewayIPKey))
+	}
+
+	res, err := rs.client.Post(ctx, rs.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *staticRoute
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return staticRouteStructToInterface(obj, rs.client), nil
+}
+
+func staticRouteStructSliceToInterface(in []*staticRoute, client Client) []StaticRoute {
+	var out []StaticRoute
+	for _, r := range in {
+		out = append(out, staticRouteStructToInterface(r, client))
+	}
+	return out
+}
+
+func staticRouteStructToInterface(in *staticRoute, client Client) StaticRoute {
+	in.client = client
+	in.apiPath = fmt.Sprintf(StaticRouteAPIPathFormat, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type staticRoute struct {
+	Controller
+	id          int
+	source      *subnet
+	destination *subnet
+	gatewayIP   net.IP
+	metric      int
+}
+
+func (r *staticRoute) Get(ctx context.Context) (StaticRoute, error) {
+	res, err := r.client.Get(ctx, r.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *staticRoute) Delete(ctx context.Context) error {
+	res, err := r.client.Delete(ctx, r.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (r *staticRoute) Modifier() StaticRouteModifier {
+	r.params.Reset()
+	return r
+}
+
+func (r *staticRoute) SetSource(subnetID int) StaticRouteModifier {
+	r.params.Set(SourceKey, strconv.Itoa(subnetID))
+	return r
+}
+
+func (r *staticRoute) SetDestination(subnetID int) StaticRouteModifier {
+	r.params.Set(DestinationKey, strconv.Itoa(subnetID))
+	return r
+}
+
+func (r *staticRoute) SetGatewayIP(ip string) StaticRouteModifier {
+	r.params.Set(GatewayIPKey, ip)
+	return r
+}
+
+func (r *staticRoute) SetMetric(metric int) StaticRouteModifier {
+	r.params.Set(MetricKey, strconv.Itoa(metric))
+	return r
+}
+
+func (r *staticRoute) Update(ctx context.Context) (StaticRoute, error) {
+	values := r.params.Values()
+	if values.Has(GatewayIPKey) && net.ParseIP(values.Get(GatewayIPKey)) == nil {
+		return nil, fmt.Errorf("invalid gateway IP %q", values.Get(GatewayIPKey))
+	}
+
+	res, err := r.client.PutParams(ctx, r.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, unMarshalJson(res, &r)
+}
+
+func (r *staticRoute) ID() int {
+	return r.id
+}
+
+func (r *staticRoute) Source() Subnet {
+	if r.source == nil {
+		return nil
+	}
+	return subnetStructToInterface(r.source, r.client)
+}
+
+func (r *staticRoute) Destination() Subnet {
+	if r.destination == nil {
+		return nil
+	}
+	return subnetStructToInterface(r.destination, r.client)
+}
+
+func (r *staticRoute) GatewayIP() net.IP {
+	return r.gatewayIP
+}
+
+func (r *staticRoute) Metric() int {
+	return r.metric
+}
+
+func (r *staticRoute) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID          int     `json:"id"`
+		Source      *subnet `json:"source"`
+		Destination *subnet `json:"destination"`
+		GatewayIP   string  `json:"gateway_ip"`
+		Metric      int     `json:"metric"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	r.id = des.ID
+	r.source = des.Source
+	r.destination = des.Destination
+	r.gatewayIP = net.ParseIP(des.GatewayIP)
+	r.metric = des.Metric
+
+	return nil
+}
+
+func NewStaticRoutesClient(client Client) StaticRoutes {
+	return &staticRoutes{
+		Controller: Controller{
+			client:  client,
+			apiPath: StaticRoutesAPIPath,
+			params:  ParamsBuilder(),
+		},
+	}
+}
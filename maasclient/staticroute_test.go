@@ -0,0 +1,94 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRoute_Unmarshal(t *testing.T) {
+	data := `{
+		"id": 1,
+		"source": {"id": 3, "cidr": "10.0.0.0/24"},
+		"destination": {"id": 4, "cidr": "192.168.10.0/24"},
+		"gateway_ip": "10.0.0.254",
+		"metric": 10
+	}`
+
+	var r *staticRoute
+	err := json.Unmarshal([]byte(data), &r)
+	assert.Nil(t, err)
+	assert.Equal(t, r.Source().CIDR(), "10.0.0.0/24")
+	assert.Equal(t, r.Destination().ID(), 4)
+	assert.Equal(t, r.GatewayIP().String(), "10.0.0.254")
+	assert.Equal(t, r.Metric(), 10)
+}
+
+func TestStaticRoutes(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with two existing subnets and a gateway in the source subnet
+	sourceCIDR := "REPLACE_WITH_SOURCE_CIDR"
+	destinationCIDR := "REPLACE_WITH_DESTINATION_CIDR"
+	gatewayIP := "REPLACE_WITH_GATEWAY_IP"
+
+	t.Run("route without gateway", func(t *testing.T) {
+		res, err := c.StaticRoutes().Builder().WithSource(1).WithDestination(2).Create(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("create, update and delete route", func(t *testing.T) {
+		if sourceCIDR == "REPLACE_WITH_SOURCE_CIDR" {
+			t.Skip("Please replace placeholder subnet CIDRs and gateway IP")
+			return
+		}
+
+		sourceID, err := c.Subnets().GetIDByCIDR(ctx, sourceCIDR)
+		assert.Nil(t, err)
+		destinationID, err := c.Subnets().GetIDByCIDR(ctx, destinationCIDR)
+		assert.Nil(t, err)
+
+		res, err := c.StaticRoutes().Builder().
+			WithSource(sourceID).
+			WithDestination(destinationID).
+			WithGatewayIP(gatewayIP).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Source().ID(), sourceID)
+		assert.Equal(t, res.Destination().CIDR(), destinationCIDR)
+
+		res, err = res.Modifier().SetMetric(100).Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Metric(), 100)
+
+		res, err = c.StaticRoutes().StaticRoute(res.ID()).Get(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.GatewayIP().String(), gatewayIP)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// stubRequest is a request recorded by stubClient
+type stubRequest struct {
+	method string
+	path   string
+	params url.Values
+}
+
+// stubHandler answers a request made through stubClient
+type stubHandler func(req stubRequest) *http.Response
+
+// stubClient is a Client for offline tests. It records every request and answers it with the
+// handler registered for its HTTP method, requests without a handler fail.
+type stubClient struct {
+	handlers map[string]stubHandler
+	requests []stubRequest
+}
+
+func newStubClient(handlers map[string]stubHandler) *stubClient {
+	return &stubClient{handlers: handlers}
+}
+
+// stubResponse returns a response with the given status and body
+func stubResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// sent returns the recorded requests with the given method, in order
+func (c *stubClient) sent(method string) []stubRequest {
+	var out []stubRequest
+	for _, req := range c.requests {
+		if req.method == method {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// last returns the most recent request
+func (c *stubClient) last() stubRequest {
+	if len(c.requests) == 0 {
+		return stubRequest{}
+	}
+	return c.requests[len(c.requests)-1]
+}
+
+func (c *stubClient) do(method, path string, params url.Values) (*http.Response, error) {
+	req := stubRequest{method: method, path: path, params: params}
+	c.requests = append(c.requests, req)
+
+	handler, ok := c.handlers[method]
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s %s", method, path)
+	}
+	return handler(req), nil
+}
+
+func (c *stubClient) Get(ctx context.Context, path string, params url.Values) (*http.Response, error) {
+	return c.do(http.MethodGet, path, params)
+}
+
+func (c *stubClient) Post(ctx context.Context, path string, params url.Values) (*http.Response, error) {
+	return c.do(http.MethodPost, path, params)
+}
+
+func (c *stubClient) PostForm(ctx context.Context, path string, contentType string, params url.Values, body io.Reader) (*http.Response, error) {
+	return c.do(http.MethodPost, path, params)
+}
+
+func (c *stubClient) Put(ctx context.Context, path string, params url.Values, body io.Reader, contentLength int) (*http.Response, error) {
+	return c.do(http.MethodPut, path, params)
+}
+
+func (c *stubClient) PutParams(ctx context.Context, path string, params url.Values) (*http.Response, error) {
+	return c.do(http.MethodPut, path, params)
+}
+
+func (c *stubClient) Delete(ctx context.Context, path string, params url.Values) (*http.Response, error) {
+	return c.do(http.MethodDelete, path, params)
+}
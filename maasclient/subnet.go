@@ -16,22 +16,178 @@ limitations under the License.
 
 package maasclient
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
 
 type Subnet interface {
+	Get(ctx context.Context) (Subnet, error)
+	Delete(ctx context.Context) error
+	// Modifier returns a modifier for the subnet. Changes are validated like SubnetBuilder
+	// does, a new gateway is checked against the new CIDR or the current one.
+	Modifier() SubnetModifier
 	ID() int
 	Name() string
+	Description() string
 	Space() string
 	VLAN() VLAN
 	CIDR() string
+	// GatewayIP returns the default gateway of the subnet, or nil if none is set
+	GatewayIP() net.IP
+	DNSServers() []net.IP
+	// Managed returns true when MAAS manages IP allocation on the subnet
+	Managed() bool
+	AllowDNS() bool
+	AllowProxy() bool
+	ActiveDiscovery() bool
+	// RDNSMode returns one of the RDNSMode* constants
+	RDNSMode() int
+}
+
+type SubnetModifier interface {
+	SetCIDR(cidr string) SubnetModifier
+	SetName(name string) SubnetModifier
+	SetDescription(description string) SubnetModifier
+	// SetVLAN moves the subnet to the VLAN with the given ID
+	SetVLAN(vlanID int) SubnetModifier
+	// SetGatewayIP sets the default gateway, an empty string removes it
+	SetGatewayIP(gatewayIP string) SubnetModifier
+	// SetDNSServers sets the DNS servers, an empty slice removes them
+	SetDNSServers(dnsServers []string) SubnetModifier
+	SetManaged(managed bool) SubnetModifier
+	SetAllowDNS(allowDNS bool) SubnetModifier
+	SetAllowProxy(allowProxy bool) SubnetModifier
+	SetActiveDiscovery(activeDiscovery bool) SubnetModifier
+	SetRDNSMode(mode int) SubnetModifier
+	Update(ctx context.Context) (Subnet, error)
 }
 
 type subnet struct {
-	id    int
-	name  string
-	space string
-	vlan  *vLAN
-	cidr  string
+	Controller
+	id              int
+	name            string
+	description     string
+	space           string
+	vlan            *vlan
+	cidr            string
+	gatewayIP       net.IP
+	dnsServers      []net.IP
+	managed         bool
+	allowDNS        bool
+	allowProxy      bool
+	activeDiscovery bool
+	rdnsMode        int
+}
+
+func (s *subnet) Get(ctx context.Context) (Subnet, error) {
+	res, err := s.client.Get(ctx, s.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, unMarshalJson(res, &s)
+}
+
+func (s *subnet) Delete(ctx context.Context) error {
+	res, err := s.client.Delete(ctx, s.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (s *subnet) Modifier() SubnetModifier {
+	s.params.Reset()
+	return s
+}
+
+func (s *subnet) SetCIDR(cidr string) SubnetModifier {
+	s.params.Set(CIDRKey, cidr)
+	return s
+}
+
+func (s *subnet) SetName(name string) SubnetModifier {
+	s.params.Set(NameKey, name)
+	return s
+}
+
+func (s *subnet) SetDescription(description string) SubnetModifier {
+	s.params.Set(DescriptionKey, description)
+	return s
+}
+
+func (s *subnet) SetVLAN(vlanID int) SubnetModifier {
+	s.params.Set(VLANKey, strconv.Itoa(vlanID))
+	return s
+}
+
+func (s *subnet) SetGatewayIP(gatewayIP string) SubnetModifier {
+	s.params.Set(GatewayIPKey, gatewayIP)
+	return s
+}
+
+func (s *subnet) SetDNSServers(dnsServers []string) SubnetModifier {
+	s.params.Set(DNSServersKey, strings.Join(dnsServers, " "))
+	return s
+}
+
+func (s *subnet) SetManaged(managed bool) SubnetModifier {
+	s.params.Set(ManagedKey, strconv.FormatBool(managed))
+	return s
+}
+
+func (s *subnet) SetAllowDNS(allowDNS bool) SubnetModifier {
+	s.params.Set(AllowDNSKey, strconv.FormatBool(allowDNS))
+	return s
+}
+
+func (s *subnet) SetAllowProxy(allowProxy bool) SubnetModifier {
+	s.params.Set(AllowProxyKey, strconv.FormatBool(allowProxy))
+	return s
+}
+
+func (s *subnet) SetActiveDiscovery(activeDiscovery bool) SubnetModifier {
+	s.params.Set(ActiveDiscoveryKey, strconv.FormatBool(activeDiscovery))
+	return s
+}
+
+func (s *subnet) SetRDNSMode(mode int) SubnetModifier {
+	s.params.Set(RDNSModeKey, strconv.Itoa(mode))
+	return s
+}
+
+func (s *subnet) Update(ctx context.Context) (Subnet, error) {
+	values := s.params.Values()
+
+	// A new gateway without a new CIDR is checked against the current CIDR
+	var current *net.IPNet
+	if values.Get(CIDRKey) == "" && values.Get(GatewayIPKey) != "" {
+		if s.cidr == "" {
+			if _, err := s.Get(ctx); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if _, current, err = net.ParseCIDR(s.cidr); err != nil {
+			return nil, fmt.Errorf("subnet %d has invalid CIDR %q: %w", s.id, s.cidr, err)
+		}
+	}
+	if err := validateSubnetParams(values, current); err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.PutParams(ctx, s.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, unMarshalJson(res, &s)
 }
 
 func (s *subnet) ID() int {
@@ -42,25 +198,68 @@ func (s *subnet) Name() string {
 	return s.name
 }
 
+func (s *subnet) Description() string {
+	return s.description
+}
+
 func (s *subnet) Space() string {
 	return s.space
 }
 
 func (s *subnet) VLAN() VLAN {
-	return s.vlan
+	if s.vlan == nil {
+		return nil
+	}
+	return vlanStructToInterface(s.vlan, s.client)
 }
 
 func (s *subnet) CIDR() string {
 	return s.cidr
 }
 
+func (s *subnet) GatewayIP() net.IP {
+	return s.gatewayIP
+}
+
+func (s *subnet) DNSServers() []net.IP {
+	return s.dnsServers
+}
+
+func (s *subnet) Managed() bool {
+	return s.managed
+}
+
+func (s *subnet) AllowDNS() bool {
+	return s.allowDNS
+}
+
+func (s *subnet) AllowProxy() bool {
+	return s.allowProxy
+}
+
+func (s *subnet) ActiveDiscovery() bool {
+	return s.activeDiscovery
+}
+
+func (s *subnet) RDNSMode() int {
+	return s.rdnsMode
+}
+
 func (s *subnet) UnmarshalJSON(data []byte) error {
 	des := &struct {
-		Id    int    `json:"id"`
-		Name  string `json:"name"`
-		Space string `json:"space"`
-		Vlan  *vLAN  `json:"vlan"`
-		Cidr  string `json:"cidr"`
+		Id              int      `json:"id"`
+		Name            string   `json:"name"`
+		Description     string   `json:"description"`
+		Space           string   `json:"space"`
+		Vlan            *vlan    `json:"vlan"`
+		Cidr            string   `json:"cidr"`
+		GatewayIP       *string  `json:"gateway_ip"`
+		DNSServers      []string `json:"dns_servers"`
+		Managed         bool     `json:"managed"`
+		AllowDNS        bool     `json:"allow_dns"`
+		AllowProxy      bool     `json:"allow_proxy"`
+		ActiveDiscovery bool     `json:"active_discovery"`
+		RDNSMode        int      `json:"rdns_mode"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -73,14 +272,35 @@ func (s *subnet) UnmarshalJSON(data []byte) error {
 	s.space = des.Space
 	s.vlan = des.Vlan
 	s.cidr = des.Cidr
+	s.description = des.Description
+	s.gatewayIP = nil
+	if des.GatewayIP != nil {
+		s.gatewayIP = net.ParseIP(*des.GatewayIP)
+	}
+	s.dnsServers = nil
+	for _, server := range des.DNSServers {
+		s.dnsServers = append(s.dnsServers, net.ParseIP(server))
+	}
+	s.managed = des.Managed
+	s.allowDNS = des.AllowDNS
+	s.allowProxy = des.AllowProxy
+	s.activeDiscovery = des.ActiveDiscovery
+	s.rdnsMode = des.RDNSMode
 
 	return nil
 }
 
-func subnetSliceToInterface(in []*subnet) []Subnet {
+func subnetStructSliceToInterface(in []*subnet, client Client) []Subnet {
 	var out []Subnet
 	for _, s := range in {
-		out = append(out, s)
+		out = append(out, subnetStructToInterface(s, client))
 	}
 	return out
 }
+
+func subnetStructToInterface(in *subnet, client Client) Subnet {
+	in.client = client
+	in.apiPath = fmt.Sprintf(SubnetAPIPathFormat, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
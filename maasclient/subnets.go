@@ -17,13 +17,18 @@ limitations under the License.
 package maasclient
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 const (
-	SubnetsAPIPath = "/subnets/"
+	SubnetsAPIPath      = "/subnets/"
+	SubnetAPIPathFormat = "/subnets/%d/"
 )
 
 // SubnetIPAddress represents an IP address entry from a subnet's ip_addresses endpoint
@@ -35,6 +40,27 @@ type SubnetIPAddress struct {
 	User      string `json:"user"`
 }
 
+// SubnetStatistics represents the address usage of a subnet as returned by its statistics operation
+type SubnetStatistics struct {
+	NumAvailable     int     `json:"num_available"`
+	LargestAvailable int     `json:"largest_available"`
+	NumUnavailable   int     `json:"num_unavailable"`
+	TotalAddresses   int     `json:"total_addresses"`
+	Usage            float64 `json:"usage"`
+	UsageString      string  `json:"usage_string"`
+	AvailableString  string  `json:"available_string"`
+	FirstAddress     string  `json:"first_address"`
+	LastAddress      string  `json:"last_address"`
+	IPVersion        int     `json:"ip_version"`
+}
+
+// UnreservedIPRange represents a span of a subnet outside of any reserved or dynamic range
+type UnreservedIPRange struct {
+	Start        string `json:"start"`
+	End          string `json:"end"`
+	NumAddresses int    `json:"num_addresses"`
+}
+
 // Subnets interface for subnet operations
 type Subnets interface {
 	// List returns all subnets
@@ -45,6 +71,40 @@ type Subnets interface {
 	GetIPAddresses(ctx context.Context, subnetID int) ([]SubnetIPAddress, error)
 	// IsIPInUse returns true if the given IP is tracked in the given subnet
 	IsIPInUse(ctx context.Context, subnetID int, ip string) (bool, error)
+	// Subnet returns the subnet with the given ID
+	Subnet(id int) Subnet
+	// Builder returns a builder for a new subnet. The CIDR, gateway and DNS servers
+	// are validated locally before MAAS is called.
+	Builder() SubnetBuilder
+	// Statistics returns the address usage of the given subnet
+	Statistics(ctx context.Context, subnetID int) (*SubnetStatistics, error)
+	// UnreservedIPRanges returns the spans of the given subnet not covered by an IP range
+	UnreservedIPRanges(ctx context.Context, subnetID int) ([]UnreservedIPRange, error)
+	// FindFreeIPs returns count addresses outside of any IP range that aren't in use
+	FindFreeIPs(ctx context.Context, subnetID int, count int) ([]net.IP, error)
+	// FindFreeIPBlock is like FindFreeIPs, but the returned addresses are consecutive
+	FindFreeIPBlock(ctx context.Context, subnetID int, count int) ([]net.IP, error)
+}
+
+// SubnetBuilder creates a subnet. Only the CIDR is required, MAAS places the subnet on
+// the default VLAN of the given fabric (or fabric-0) when no VLAN is given.
+type SubnetBuilder interface {
+	WithCIDR(cidr string) SubnetBuilder
+	WithName(name string) SubnetBuilder
+	WithDescription(description string) SubnetBuilder
+	// WithVLAN places the subnet on the VLAN with the given ID
+	WithVLAN(vlanID int) SubnetBuilder
+	// WithFabric and WithVID select the VLAN by fabric ID and VID instead of by ID
+	WithFabric(fabricID int) SubnetBuilder
+	WithVID(vid int) SubnetBuilder
+	WithGatewayIP(gatewayIP string) SubnetBuilder
+	WithDNSServers(dnsServers []string) SubnetBuilder
+	WithManaged(managed bool) SubnetBuilder
+	WithAllowDNS(allowDNS bool) SubnetBuilder
+	WithAllowProxy(allowProxy bool) SubnetBuilder
+	WithActiveDiscovery(activeDiscovery bool) SubnetBuilder
+	WithRDNSMode(mode int) SubnetBuilder
+	Create(ctx context.Context) (Subnet, error)
 }
 
 // subnets controller implementation
@@ -65,7 +125,7 @@ func NewSubnetsClient(client *authenticatedClient) Subnets {
 
 // List returns all subnets
 func (s *subnets) List(ctx context.Context) ([]Subnet, error) {
-	res, err := s.client.Get(ctx, s.apiPath, s.params.Values())
+	res, err := s.client.Get(ctx, s.apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +136,7 @@ func (s *subnets) List(ctx context.Context) ([]Subnet, error) {
 		return nil, err
 	}
 
-	return subnetSliceToInterface(obj), nil
+	return subnetStructSliceToInterface(obj, s.client), nil
 }
 
 // GetIDByCIDR returns the subnet ID matching the given CIDR
@@ -134,3 +194,262 @@ func (s *subnets) IsIPInUse(ctx context.Context, subnetID int, ip string) (bool,
 	return false, nil
 }
 
+// Subnet returns the subnet with the given ID
+func (s *subnets) Subnet(id int) Subnet {
+	return subnetStructToInterface(&subnet{id: id}, s.client)
+}
+
+func (s *subnets) Builder() SubnetBuilder {
+	s.params.Reset()
+	return s
+}
+
+func (s *subnets) WithCIDR(cidr string) SubnetBuilder {
+	s.params.Set(CIDRKey, cidr)
+	return s
+}
+
+func (s *subnets) WithName(name string) SubnetBuilder {
+	s.params.Set(NameKey, name)
+	return s
+}
+
+func (s *subnets) WithDescription(description string) SubnetBuilder {
+	s.params.Set(DescriptionKey, description)
+	return s
+}
+
+func (s *subnets) WithVLAN(vlanID int) SubnetBuilder {
+	s.params.Set(VLANKey, strconv.Itoa(vlanID))
+	return s
+}
+
+func (s *subnets) WithFabric(fabricID int) SubnetBuilder {
+	s.params.Set(FabricKey, strconv.Itoa(fabricID))
+	return s
+}
+
+func (s *subnets) WithVID(vid int) SubnetBuilder {
+	s.params.Set(VIDKey, strconv.Itoa(vid))
+	return s
+}
+
+func (s *subnets) WithGatewayIP(gatewayIP string) SubnetBuilder {
+	s.params.Set(GatewayIPKey, gatewayIP)
+	return s
+}
+
+func (s *subnets) WithDNSServers(dnsServers []string) SubnetBuilder {
+	s.params.Set(DNSServersKey, strings.Join(dnsServers, " "))
+	return s
+}
+
+func (s *subnets) WithManaged(managed bool) SubnetBuilder {
+	s.params.Set(ManagedKey, strconv.FormatBool(managed))
+	return s
+}
+
+func (s *subnets) WithAllowDNS(allowDNS bool) SubnetBuilder {
+	s.params.Set(AllowDNSKey, strconv.FormatBool(allowDNS))
+	return s
+}
+
+func (s *subnets) WithAllowProxy(allowProxy bool) SubnetBuilder {
+	s.params.Set(AllowProxyKey, strconv.FormatBool(allowProxy))
+	return s
+}
+
+func (s *subnets) WithActiveDiscovery(activeDiscovery bool) SubnetBuilder {
+	s.params.Set(ActiveDiscoveryKey, strconv.FormatBool(activeDiscovery))
+	return s
+}
+
+func (s *subnets) WithRDNSMode(mode int) SubnetBuilder {
+	s.params.Set(RDNSModeKey, strconv.Itoa(mode))
+	return s
+}
+
+func (s *subnets) Create(ctx context.Context) (Subnet, error) {
+	values := s.params.Values()
+	if values.Get(CIDRKey) == "" {
+		return nil, fmt.Errorf("subnet CIDR is required")
+	}
+	if err := validateSubnetParams(values, nil); err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.Post(ctx, s.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *subnet
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return subnetStructToInterface(obj, s.client), nil
+}
+
+// validateSubnetParams checks the CIDR, gateway and DNS servers in values. The gateway is
+// checked against the CIDR in values, falling back to network when values has none.
+func validateSubnetParams(values url.Values, network *net.IPNet) error {
+	if cidr := values.Get(CIDRKey); cidr != "" {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid subnet CIDR %q: %w", cidr, err)
+		}
+		if !ip.Equal(ipNet.IP) {
+			return fmt.Errorf("invalid subnet CIDR %q: host bits are set, did you mean %s", cidr, ipNet)
+		}
+		network = ipNet
+	}
+
+	if gateway := values.Get(GatewayIPKey); gateway != "" {
+		ip := net.ParseIP(gateway)
+		if ip == nil {
+			return fmt.Errorf("invalid gateway IP %q", gateway)
+		}
+		if network != nil && !network.Contains(ip) {
+			return fmt.Errorf("gateway IP %s is outside of subnet %s", ip, network)
+		}
+	}
+
+	for _, server := range strings.Fields(values.Get(DNSServersKey)) {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("invalid DNS server %q", server)
+		}
+	}
+
+	if mode := values.Get(RDNSModeKey); mode != "" {
+		switch mode {
+		case strconv.Itoa(RDNSModeDisabled), strconv.Itoa(RDNSModeEnabled), strconv.Itoa(RDNSModeRFC2317):
+		default:
+			return fmt.Errorf("invalid reverse DNS mode %s", mode)
+		}
+	}
+
+	return nil
+}
+
+// Statistics returns the address usage of the given subnet
+func (s *subnets) Statistics(ctx context.Context, subnetID int) (*SubnetStatistics, error) {
+	params := url.Values{}
+	params.Set(Operation, OperationStatistics)
+
+	res, err := s.client.Get(ctx, fmt.Sprintf(SubnetAPIPathFormat, subnetID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats *SubnetStatistics
+	err = unMarshalJson(res, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// UnreservedIPRanges returns the spans of the given subnet not covered by a reserved or dynamic range
+func (s *subnets) UnreservedIPRanges(ctx context.Context, subnetID int) ([]UnreservedIPRange, error) {
+	params := url.Values{}
+	params.Set(Operation, OperationUnreservedIPRanges)
+
+	res, err := s.client.Get(ctx, fmt.Sprintf(SubnetAPIPathFormat, subnetID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges []UnreservedIPRange
+	err = unMarshalJson(res, &ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	return ranges, nil
+}
+
+// FindFreeIPs returns count free addresses of the given subnet
+func (s *subnets) FindFreeIPs(ctx context.Context, subnetID int, count int) ([]net.IP, error) {
+	return s.findFreeIPs(ctx, subnetID, count, false)
+}
+
+// FindFreeIPBlock returns count consecutive free addresses of the given subnet
+func (s *subnets) FindFreeIPBlock(ctx context.Context, subnetID int, count int) ([]net.IP, error) {
+	return s.findFreeIPs(ctx, subnetID, count, true)
+}
+
+// findFreeIPs walks the unreserved spans of the subnet in order, skipping the addresses
+// MAAS tracks as in use. With contiguous set, a used address restarts the block.
+func (s *subnets) findFreeIPs(ctx context.Context, subnetID int, count int, contiguous bool) ([]net.IP, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid number of addresses %d", count)
+	}
+
+	ranges, err := s.UnreservedIPRanges(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddresses, err := s.GetIPAddresses(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool, len(ipAddresses))
+	for _, addr := range ipAddresses {
+		if ip := net.ParseIP(addr.IP); ip != nil {
+			used[ip.String()] = true
+		}
+	}
+
+	var free []net.IP
+	for _, r := range ranges {
+		start, end := net.ParseIP(r.Start), net.ParseIP(r.End)
+		if start == nil || end == nil {
+			return nil, fmt.Errorf("invalid unreserved range %s-%s in subnet %d", r.Start, r.End, subnetID)
+		}
+
+		// A block can't span two ranges, there are reserved addresses in between
+		if contiguous {
+			free = nil
+		}
+
+		for ip := start; ip != nil && bytes.Compare(ip.To16(), end.To16()) <= 0; ip = nextIP(ip) {
+			if used[ip.String()] {
+				if contiguous {
+					free = nil
+				}
+				continue
+			}
+
+			free = append(free, ip)
+			if len(free) == count {
+				return free, nil
+			}
+		}
+	}
+
+	if contiguous {
+		return nil, fmt.Errorf("subnet %d has no block of %d free addresses", subnetID, count)
+	}
+	return nil, fmt.Errorf("subnet %d has only %d of %d requested free addresses", subnetID, len(free), count)
+}
+
+// nextIP returns the address following ip, or nil when ip is the last address of its family
+func nextIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubnet_Unmarshal(t *testing.T) {
+	data := `{
+		"id": 3, "name": "10.10.0.0/24", "cidr": "10.10.0.0/24", "description": "provisioning",
+		"gateway_ip": "10.10.0.1", "dns_servers": ["10.10.0.2", "8.8.8.8"],
+		"managed": true, "allow_dns": true, "allow_proxy": false, "active_discovery": true,
+		"rdns_mode": 2, "space": "undefined", "vlan": {"id": 5001, "vid": 0}
+	}`
+
+	var s *subnet
+	err := json.Unmarshal([]byte(data), &s)
+	assert.Nil(t, err)
+	assert.Equal(t, s.Description(), "provisioning")
+	assert.True(t, s.GatewayIP().Equal(net.ParseIP("10.10.0.1")))
+	assert.Len(t, s.DNSServers(), 2)
+	assert.True(t, s.Managed())
+	assert.True(t, s.AllowDNS())
+	assert.False(t, s.AllowProxy())
+	assert.True(t, s.ActiveDiscovery())
+	assert.Equal(t, s.RDNSMode(), RDNSModeRFC2317)
+	assert.Equal(t, s.VLAN().ID(), 5001)
+
+	err = json.Unmarshal([]byte(`{"id": 4, "gateway_ip": null, "dns_servers": []}`), &s)
+	assert.Nil(t, err)
+	assert.Nil(t, s.GatewayIP())
+	assert.Empty(t, s.DNSServers())
+}
+
+func TestSubnets_BuilderValidation(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		builder SubnetBuilder
+	}{
+		{"missing cidr", c.Subnets().Builder().WithName("no-cidr")},
+		{"invalid cidr", c.Subnets().Builder().WithCIDR("10.10.0.0/33")},
+		{"host bits set", c.Subnets().Builder().WithCIDR("10.10.0.1/24")},
+		{"gateway outside cidr", c.Subnets().Builder().WithCIDR("10.10.0.0/24").WithGatewayIP("10.20.0.1")},
+		{"invalid dns server", c.Subnets().Builder().WithCIDR("10.10.0.0/24").WithDNSServers([]string{"dns.example.com"})},
+		{"invalid rdns mode", c.Subnets().Builder().WithCIDR("10.10.0.0/24").WithRDNSMode(3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.builder.Create(ctx)
+			assert.NotNil(t, err)
+			assert.Nil(t, res)
+		})
+	}
+
+	t.Run("update gateway outside new cidr", func(t *testing.T) {
+		res, err := c.Subnets().Subnet(0).Modifier().SetCIDR("10.10.0.0/24").SetGatewayIP("10.20.0.1").Update(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSubnets(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	t.Run("create, update and delete subnet", func(t *testing.T) {
+		res, err := c.Subnets().Builder().
+			WithCIDR("10.99.0.0/24").
+			WithName("subnet-test").
+			WithGatewayIP("10.99.0.1").
+			WithDNSServers([]string{"10.99.0.2"}).
+			WithRDNSMode(RDNSModeEnabled).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.CIDR(), "10.99.0.0/24")
+		assert.True(t, res.GatewayIP().Equal(net.ParseIP("10.99.0.1")))
+
+		res, err = res.Modifier().SetDescription("updated").SetManaged(false).Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Description(), "updated")
+		assert.False(t, res.Managed())
+
+		_, err = res.Modifier().SetGatewayIP("10.98.0.1").Update(ctx)
+		assert.NotNil(t, err)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
+
+func TestSubnet_Modifier(t *testing.T) {
+	client := newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 7, "cidr": "10.0.0.0/24", "vlan": {"id": 5001, "vid": 0, "fabric_id": 0}}`)
+		},
+		http.MethodPut: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 7, "cidr": "10.0.0.0/24", "gateway_ip": "10.0.0.1"}`)
+		},
+	})
+	s := &subnets{Controller: Controller{client: client, apiPath: SubnetsAPIPath, params: ParamsBuilder()}}
+	ctx := context.Background()
+
+	t.Run("gateway is checked against the current cidr", func(t *testing.T) {
+		_, err := s.Subnet(7).Modifier().SetGatewayIP("10.1.0.1").Update(ctx)
+		assert.NotNil(t, err)
+		assert.Len(t, client.sent(http.MethodGet), 1)
+		assert.Empty(t, client.sent(http.MethodPut))
+	})
+
+	t.Run("invalid dns server", func(t *testing.T) {
+		_, err := s.Subnet(7).Modifier().SetDNSServers([]string{"dns.example.com"}).Update(ctx)
+		assert.NotNil(t, err)
+		assert.Empty(t, client.sent(http.MethodPut))
+	})
+
+	t.Run("valid update", func(t *testing.T) {
+		res, err := s.Subnet(7).Modifier().SetGatewayIP("10.0.0.1").SetDNSServers(nil).Update(ctx)
+		assert.Nil(t, err)
+		assert.True(t, res.GatewayIP().Equal(net.ParseIP("10.0.0.1")))
+		assert.Equal(t, client.last().path, "/subnets/7/")
+		assert.Equal(t, client.last().params, url.Values{GatewayIPKey: {"10.0.0.1"}, DNSServersKey: {""}})
+	})
+}
+
+// newFreeIPStubClient serves the unreserved ranges and used addresses of a subnet
+func newFreeIPStubClient(unreservedRanges, usedAddresses string) *stubClient {
+	return newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			switch req.params.Get(Operation) {
+			case OperationUnreservedIPRanges:
+				return stubResponse(http.StatusOK, unreservedRanges)
+			case OperationStatistics:
+				return stubResponse(http.StatusOK, `{"num_available": 57, "largest_available": 53, "total_addresses": 254, "usage": 0.77}`)
+			default:
+				return stubResponse(http.StatusOK, usedAddresses)
+			}
+		},
+	})
+}
+
+func TestSubnets_FindFreeIPs(t *testing.T) {
+	client := newFreeIPStubClient(`[
+		{"start": "10.0.0.2", "end": "10.0.0.5", "num_addresses": 4},
+		{"start": "10.0.0.200", "end": "10.0.0.254", "num_addresses": 55}
+	]`, `[{"ip": "10.0.0.3"}, {"ip": "10.0.0.201"}]`)
+	s := &subnets{Controller: Controller{client: client, apiPath: SubnetsAPIPath, params: ParamsBuilder()}}
+
+	ctx := context.Background()
+
+	toStrings := func(ips []net.IP) []string {
+		var out []string
+		for _, ip := range ips {
+			out = append(out, ip.String())
+		}
+		return out
+	}
+
+	t.Run("statistics", func(t *testing.T) {
+		res, err := s.Statistics(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, res.NumAvailable, 57)
+		assert.Equal(t, res.LargestAvailable, 53)
+	})
+
+	t.Run("free addresses skip used ones", func(t *testing.T) {
+		res, err := s.FindFreeIPs(ctx, 1, 4)
+		assert.Nil(t, err)
+		assert.Equal(t, toStrings(res), []string{"10.0.0.2", "10.0.0.4", "10.0.0.5", "10.0.0.200"})
+	})
+
+	t.Run("block restarts after used address", func(t *testing.T) {
+		res, err := s.FindFreeIPBlock(ctx, 1, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, toStrings(res), []string{"10.0.0.202", "10.0.0.203", "10.0.0.204"})
+	})
+
+	t.Run("not enough addresses", func(t *testing.T) {
+		res, err := s.FindFreeIPs(ctx, 1, 100)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestNextIP(t *testing.T) {
+	assert.Equal(t, nextIP(net.ParseIP("10.0.0.255")).String(), "10.0.1.0")
+	assert.Equal(t, nextIP(net.ParseIP("2001:db8::ffff")).String(), "2001:db8::1:0")
+	assert.Nil(t, nextIP(net.ParseIP("255.255.255.255")))
+	assert.Nil(t, nextIP(net.ParseIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")))
+}
+
+func TestSubnets_FindFreeIPsLastAddress(t *testing.T) {
+	ctx := context.Background()
+
+	for _, r := range []struct{ start, end string }{
+		{"255.255.255.253", "255.255.255.255"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	} {
+		client := newFreeIPStubClient(`[{"start": "`+r.start+`", "end": "`+r.end+`", "num_addresses": 3}]`, `[]`)
+		s := &subnets{Controller: Controller{client: client, apiPath: SubnetsAPIPath, params: ParamsBuilder()}}
+
+		res, err := s.FindFreeIPs(ctx, 1, 3)
+		assert.Nil(t, err)
+		assert.Len(t, res, 3)
+		assert.Equal(t, res[2].String(), r.end)
+
+		_, err = s.FindFreeIPs(ctx, 1, 4)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestSubnets_Statistics(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	t.Run("statistics and free addresses", func(t *testing.T) {
+		list, err := c.Subnets().List(ctx)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, list)
+
+		stats, err := c.Subnets().Statistics(ctx, list[0].ID())
+		assert.Nil(t, err)
+		assert.NotZero(t, stats.TotalAddresses)
+
+		ranges, err := c.Subnets().UnreservedIPRanges(ctx, list[0].ID())
+		assert.Nil(t, err)
+		assert.NotEmpty(t, ranges)
+
+		ips, err := c.Subnets().FindFreeIPBlock(ctx, list[0].ID(), 2)
+		assert.Nil(t, err)
+		assert.Len(t, ips, 2)
+	})
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// TagDefinition is a compiled XPath tag definition that can be evaluated against
+// commissioning data without MAAS
+type TagDefinition struct {
+	definition string
+	expr       *xpath.Expr
+}
+
+// TagEvaluation reports the machines a definition matches. Errors holds, by system ID, the
+// machines whose details couldn't be fetched or evaluated.
+type TagEvaluation struct {
+	Matched []string
+	Errors  map[string]error
+}
+
+// CompileTagDefinition parses an XPath tag definition
+func CompileTagDefinition(definition string) (*TagDefinition, error) {
+	expr, err := xpath.Compile(definition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag definition %q: %w", definition, err)
+	}
+
+	return &TagDefinition{definition: definition, expr: expr}, nil
+}
+
+func (d *TagDefinition) String() string {
+	return d.definition
+}
+
+// Matches evaluates the definition against the details of a machine the way MAAS does: the
+// lshw document is merged under a list root element, and every document is also added with
+// its element names prefixed by lshw: or lldp:. A definition matches when its result is true
+// per the XPath boolean() function.
+func (d *TagDefinition) Matches(details *MachineDetails) (matches bool, err error) {
+	root, err := mergeDetails(details)
+	if err != nil {
+		return false, err
+	}
+
+	// The xpath package panics on some comparisons it doesn't support
+	defer func() {
+		if r := recover(); r != nil {
+			matches, err = false, fmt.Errorf("evaluating tag definition %q: %v", d.definition, r)
+		}
+	}()
+
+	switch v := d.expr.Evaluate(xmlquery.CreateXPathNavigator(root)).(type) {
+	case *xpath.NodeIterator:
+		return v.MoveNext(), nil
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0 && !math.IsNaN(v), nil
+	case string:
+		return v != "", nil
+	default:
+		return false, fmt.Errorf("unexpected result %T for tag definition %q", v, d.definition)
+	}
+}
+
+func mergeDetails(details *MachineDetails) (*xmlquery.Node, error) {
+	root := &xmlquery.Node{Type: xmlquery.DocumentNode}
+	list := &xmlquery.Node{Type: xmlquery.ElementNode, Data: "list"}
+	xmlquery.AddChild(root, list)
+
+	if details != nil && len(details.LSHW) > 0 {
+		doc, err := xmlquery.Parse(bytes.NewReader(details.LSHW))
+		if err != nil {
+			return nil, fmt.Errorf("invalid lshw details: %w", err)
+		}
+
+		// Recent lshw versions already wrap their output in a list element
+		parent := doc
+		if top := elementChildren(doc); len(top) == 1 && top[0].Data == "list" {
+			parent = top[0]
+		}
+		for _, n := range children(parent) {
+			if n.Type == xmlquery.DeclarationNode {
+				continue
+			}
+			xmlquery.RemoveFromTree(n)
+			xmlquery.AddChild(list, n)
+		}
+	}
+
+	if details != nil {
+		for _, detail := range []struct {
+			name string
+			data []byte
+		}{{"lldp", details.LLDP}, {"lshw", details.LSHW}} {
+			if len(detail.data) == 0 {
+				continue
+			}
+			doc, err := xmlquery.Parse(bytes.NewReader(detail.data))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s details: %w", detail.name, err)
+			}
+			for _, n := range elementChildren(doc) {
+				prefixElements(n, detail.name)
+				xmlquery.RemoveFromTree(n)
+				xmlquery.AddChild(list, n)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// children returns a snapshot of the children of n so they can be moved
+func children(n *xmlquery.Node) []*xmlquery.Node {
+	var out []*xmlquery.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		out = append(out, c)
+	}
+	return out
+}
+
+func elementChildren(n *xmlquery.Node) []*xmlquery.Node {
+	var out []*xmlquery.Node
+	for _, c := range children(n) {
+		if c.Type == xmlquery.ElementNode {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// prefixElements sets prefix on n and its descendant elements, attributes keep their names
+func prefixElements(n *xmlquery.Node, prefix string) {
+	if n.Type == xmlquery.ElementNode {
+		n.Prefix = prefix
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		prefixElements(c, prefix)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lshwFixture is trimmed lshw output as captured from a commissioned machine
+const lshwFixture = `<?xml version="1.0" standalone="yes" ?>
+<!-- generated by lshw-B.02.18 -->
+<list>
+<node id="node01" claimed="true" class="system" handle="DMI:0001">
+ <description>Rack Mount Chassis</description>
+ <product>PowerEdge R640</product>
+ <vendor>Dell Inc.</vendor>
+ <node id="core" claimed="true" class="bus" handle="DMI:0002">
+  <node id="cpu:0" claimed="true" class="processor" handle="DMI:0400">
+   <product>Intel(R) Xeon(R) Gold 6130 CPU @ 2.10GHz</product>
+   <size units="Hz">2100000000</size>
+   <capabilities>
+    <capability id="vmx" >Virtualization extensions</capability>
+   </capabilities>
+  </node>
+  <node id="cpu:1" claimed="true" class="processor" handle="DMI:0401">
+   <product>Intel(R) Xeon(R) Gold 6130 CPU @ 2.10GHz</product>
+   <size units="Hz">2100000000</size>
+  </node>
+  <node id="memory" claimed="true" class="memory" handle="DMI:1000">
+   <size units="bytes">274877906944</size>
+  </node>
+  <node id="network" claimed="true" class="network" handle="PCI:0000:18:00.0">
+   <product>MT27710 Family [ConnectX-4 Lx]</product>
+   <vendor>Mellanox Technologies</vendor>
+   <logicalname>ens1f0</logicalname>
+   <serial>b8:59:9f:00:00:01</serial>
+   <capacity>25000000000</capacity>
+  </node>
+ </node>
+</node>
+</list>`
+
+const lldpFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<lldp label="LLDP neighbors">
+ <interface label="Interface" name="ens1f0" via="LLDP">
+  <chassis label="Chassis">
+   <name label="SysName">tor-sw-07</name>
+  </chassis>
+  <port label="Port">
+   <descr label="PortDescr">Ethernet12</descr>
+  </port>
+ </interface>
+</lldp>`
+
+func TestTagDefinition_Matches(t *testing.T) {
+	details := &MachineDetails{LSHW: []byte(lshwFixture), LLDP: []byte(lldpFixture)}
+
+	tests := []struct {
+		definition string
+		matches    bool
+	}{
+		{`//node[@id="network"]/vendor = "Mellanox Technologies"`, true},
+		{`//node[@class="network"]/vendor[contains(., "Intel")]`, false},
+		{`count(//node[@class="processor"]) >= 2`, true},
+		{`count(//node[@class="processor"]) > 2`, false},
+		{`//node[@id="memory"]/size div 1073741824 >= 256`, true},
+		{`//capability[@id="vmx"] and not(//capability[@id="svm"])`, true},
+		{`//node[@class="network"]/capacity >= 25000000000`, true},
+		{`//node[starts-with(@id, "cpu:")][last()][@id = "cpu:1"]`, true},
+		{`/list/node[1]/product = "PowerEdge R640"`, true},
+		{`//lldp:interface[@name="ens1f0"]/lldp:chassis/lldp:name = "tor-sw-07"`, true},
+		{`//lldp:interface/chassis`, false},
+		{`//lshw:node[@id="core"]`, true},
+		{`//lldp:lldp//name[. = "tor-sw-08"]`, false},
+		{`//node[translate(vendor, "DEL", "del") = "dell Inc."]`, true},
+		{`//cpu`, false},
+		{`count(//lshw:node) = count(//node)`, true},
+		{`//lshw:node[@id="memory"]/lshw:size >= 274877906944`, true},
+		{`//lshw:node/size`, false},
+		{`//interface`, false},
+		{`name(//lldp:chassis) = "lldp:chassis" and local-name(//lldp:chassis) = "chassis"`, true},
+		{`name(/list/*[last()]) = "lshw:list"`, true},
+		{`//node[@id="cpu:1"]/preceding-sibling::node[1]/@id = "cpu:0"`, true},
+		{`count(//node[@id="memory"] | //node[@class="memory"]) = 1`, true},
+	}
+
+	for _, tc := range tests {
+		d, err := CompileTagDefinition(tc.definition)
+		if !assert.Nil(t, err, tc.definition) {
+			continue
+		}
+		matches, err := d.Matches(details)
+		assert.Nil(t, err, tc.definition)
+		assert.Equal(t, tc.matches, matches, tc.definition)
+	}
+
+	t.Run("invalid definitions", func(t *testing.T) {
+		for _, definition := range []string{`//node[`, `//node[@id=$id]`, `foo(//node)`, `//node[@id="x`} {
+			_, err := CompileTagDefinition(definition)
+			assert.NotNil(t, err, definition)
+		}
+	})
+
+	t.Run("without details", func(t *testing.T) {
+		d, err := CompileTagDefinition(`//node`)
+		assert.Nil(t, err)
+		matches, err := d.Matches(&MachineDetails{})
+		assert.Nil(t, err)
+		assert.False(t, matches)
+	})
+
+	t.Run("unsupported comparison", func(t *testing.T) {
+		d, err := CompileTagDefinition(`//cpu = false()`)
+		assert.Nil(t, err)
+		_, err = d.Matches(details)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("malformed details", func(t *testing.T) {
+		d, err := CompileTagDefinition(`//node`)
+		assert.Nil(t, err)
+		_, err = d.Matches(&MachineDetails{LSHW: []byte(`<list><node>`)})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestTags_Evaluate(t *testing.T) {
+	details := map[string][]byte{
+		"abc123": encodeDetailsBSON(map[string][]byte{"lshw": []byte(lshwFixture), "lldp": []byte(lldpFixture)}),
+		"def456": encodeDetailsBSON(map[string][]byte{"lshw": []byte(`<list><node id="node01" class="system"/></list>`)}),
+	}
+	client := newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			if req.path == "/machines/" {
+				return stubResponse(http.StatusOK, `[{"system_id": "abc123"}, {"system_id": "def456"}]`)
+			}
+			for systemID, doc := range details {
+				if req.path == "/machines/"+systemID+"/" && req.params.Get(Operation) == OperationDetails {
+					return stubResponse(http.StatusOK, string(doc))
+				}
+			}
+			return stubResponse(http.StatusNotFound, "Not Found")
+		},
+	})
+	ts := &tags{Controller: Controller{client: client, apiPath: TagsAPIPath, params: ParamsBuilder()}}
+	ctx := context.Background()
+
+	res, err := ts.Evaluate(ctx, `//node[@class="network"]/vendor = "Mellanox Technologies"`, []string{"abc123", "def456", "missing"})
+	assert.Nil(t, err)
+	assert.Equal(t, res.Matched, []string{"abc123"})
+	assert.Len(t, res.Errors, 1)
+	assert.True(t, isNotFound(res.Errors["missing"]))
+
+	res, err = ts.Evaluate(ctx, `//node[@class="system"]`, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, res.Matched, []string{"abc123", "def456"})
+	assert.Empty(t, res.Errors)
+
+	_, err = ts.Evaluate(ctx, `//node[`, nil)
+	assert.NotNil(t, err)
+}
+
+func TestTags_EvaluateLive(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	systemID := "REPLACE_WITH_MACHINE_SYSTEM_ID"
+	if systemID == "REPLACE_WITH_MACHINE_SYSTEM_ID" {
+		t.Skip("Skipping test - please replace REPLACE_WITH_MACHINE_SYSTEM_ID with a commissioned machine")
+	}
+
+	res, err := c.Tags().Evaluate(context.Background(), `//node[@class="system"]`, []string{systemID})
+	assert.Nil(t, err)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, res.Matched, []string{systemID})
+}
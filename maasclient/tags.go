@@ -24,7 +24,8 @@ import (
 )
 
 const (
-	TagsAPIPath = "/tags/"
+	TagsAPIPath      = "/tags/"
+	TagAPIPathFormat = "/tags/%s/"
 )
 
 type Tags interface {
@@ -35,16 +36,67 @@ type Tags interface {
 	Assign(ctx context.Context, tagName string, systemID string) error
 	// Unassign removes the given tag name from the provided machine system ID
 	Unassign(ctx context.Context, tagName string, systemID string) error
+	// Tag returns the tag with the given name, MAAS addresses tags by name
+	Tag(name string) Tag
+	// Builder returns a builder for a tag with a definition, comment or kernel options
+	Builder() TagBuilder
+	// Evaluate dry-runs an XPath tag definition against the commissioning details of the given
+	// machines, or of all machines when none are given, without creating the tag
+	Evaluate(ctx context.Context, definition string, systemIDs []string) (*TagEvaluation, error)
 }
 
 type Tag interface {
+	Get(ctx context.Context) (Tag, error)
+	Delete(ctx context.Context) error
+	Modifier() TagModifier
+	// Machines, Devices and Controllers return the nodes of each kind carrying the tag
+	Machines(ctx context.Context) ([]Machine, error)
+	Devices(ctx context.Context) ([]NodeSummary, error)
+	// Controllers returns the rack and region controllers carrying the tag
+	Controllers(ctx context.Context) ([]NodeSummary, error)
+	// Rebuild re-evaluates the definition of an automatic tag against all nodes in the background
+	Rebuild(ctx context.Context) error
+	// UpdateNodes adds and removes the tag on the given nodes in a single request
+	UpdateNodes(ctx context.Context, add, remove []string) (*TagNodesUpdate, error)
 	Name() string
+	// Definition returns the XPath expression nodes are tagged automatically by, if any
 	Definition() string
 	Comment() string
+	// KernelOpts returns the kernel options of nodes carrying the tag
 	KernelOpts() string
 	ResourceUri() string
 }
 
+type TagBuilder interface {
+	WithName(name string) TagBuilder
+	WithDefinition(definition string) TagBuilder
+	WithComment(comment string) TagBuilder
+	WithKernelOpts(kernelOpts string) TagBuilder
+	Create(ctx context.Context) (Tag, error)
+}
+
+type TagModifier interface {
+	SetName(name string) TagModifier
+	// SetDefinition changes the XPath expression, MAAS re-evaluates it against all nodes
+	SetDefinition(definition string) TagModifier
+	SetComment(comment string) TagModifier
+	SetKernelOpts(kernelOpts string) TagModifier
+	Update(ctx context.Context) (Tag, error)
+}
+
+// NodeSummary identifies a node carrying a tag
+type NodeSummary struct {
+	SystemID string `json:"system_id"`
+	Hostname string `json:"hostname"`
+	FQDN     string `json:"fqdn"`
+}
+
+// TagNodesUpdate reports how many nodes an update_nodes call tagged and untagged
+type TagNodesUpdate struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+}
+
 type tags struct {
 	Controller
 }
@@ -78,10 +130,7 @@ func (ds *tags) Assign(ctx context.Context, tagName string, systemID string) err
 	if tagName == "" || systemID == "" {
 		return nil
 	}
-	params := url.Values{}
-	params.Set("add", systemID)
-	path := fmt.Sprintf("%s%s/op-update_nodes", ds.apiPath, url.PathEscape(tagName))
-	_, err := ds.client.Post(ctx, path, params)
+	_, err := ds.Tag(tagName).UpdateNodes(ctx, []string{systemID}, nil)
 	return err
 
 }
@@ -90,13 +139,105 @@ func (ds *tags) Unassign(ctx context.Context, tagName string, systemID string) e
 	if tagName == "" || systemID == "" {
 		return nil
 	}
-	params := url.Values{}
-	params.Set("remove", systemID)
-	path := fmt.Sprintf("%s%s/op-update_nodes", ds.apiPath, url.PathEscape(tagName))
-	_, err := ds.client.Post(ctx, path, params)
+	_, err := ds.Tag(tagName).UpdateNodes(ctx, nil, []string{systemID})
 	return err
 }
 
+func (ds *tags) Tag(name string) Tag {
+	return tagStructToInterface(&tag{name: name}, ds.client)
+}
+
+func (ds *tags) Builder() TagBuilder {
+	return &tagBuilder{
+		Controller: Controller{
+			client:  ds.client,
+			apiPath: ds.apiPath,
+			params:  ParamsBuilder(),
+		},
+	}
+}
+
+type tagBuilder struct {
+	Controller
+}
+
+func (b *tagBuilder) WithName(name string) TagBuilder {
+	b.params.Set(NameKey, name)
+	return b
+}
+
+func (b *tagBuilder) WithDefinition(definition string) TagBuilder {
+	b.params.Set(DefinitionKey, definition)
+	return b
+}
+
+func (b *tagBuilder) WithComment(comment string) TagBuilder {
+	b.params.Set(CommentKey, comment)
+	return b
+}
+
+func (b *tagBuilder) WithKernelOpts(kernelOpts string) TagBuilder {
+	b.params.Set(KernelOptsKey, kernelOpts)
+	return b
+}
+
+func (b *tagBuilder) Create(ctx context.Context) (Tag, error) {
+	if b.params.Values().Get(NameKey) == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	res, err := b.client.Post(ctx, b.apiPath, b.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *tag
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagStructToInterface(obj, b.client), nil
+}
+
+func (ds *tags) Evaluate(ctx context.Context, definition string, systemIDs []string) (*TagEvaluation, error) {
+	d, err := CompileTagDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &machines{Controller: Controller{client: ds.client, apiPath: "/machines/", params: ParamsBuilder()}}
+	var targets []Machine
+	if len(systemIDs) == 0 {
+		if targets, err = ms.List(ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+	for _, systemID := range systemIDs {
+		targets = append(targets, ms.Machine(systemID))
+	}
+
+	evaluation := &TagEvaluation{Errors: map[string]error{}}
+	for _, m := range targets {
+		details, err := m.Details(ctx)
+		if err != nil {
+			evaluation.Errors[m.SystemID()] = err
+			continue
+		}
+
+		matched, err := d.Matches(details)
+		if err != nil {
+			evaluation.Errors[m.SystemID()] = err
+			continue
+		}
+		if matched {
+			evaluation.Matched = append(evaluation.Matched, m.SystemID())
+		}
+	}
+
+	return evaluation, nil
+}
+
 func tagsStructSliceToInterface(in []*tag, client Client) []Tag {
 	var out []Tag
 	for _, d := range in {
@@ -107,7 +248,7 @@ func tagsStructSliceToInterface(in []*tag, client Client) []Tag {
 
 func tagStructToInterface(in *tag, client Client) Tag {
 	in.client = client
-	in.apiPath = TagsAPIPath
+	in.apiPath = fmt.Sprintf(TagAPIPathFormat, url.PathEscape(in.name))
 	in.params = ParamsBuilder()
 	return in
 }
@@ -121,6 +262,154 @@ type tag struct {
 	Controller
 }
 
+func (d *tag) Get(ctx context.Context) (Tag, error) {
+	res, err := d.client.Get(ctx, d.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, unMarshalJson(res, &d)
+}
+
+func (d *tag) Delete(ctx context.Context) error {
+	res, err := d.client.Delete(ctx, d.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (d *tag) Modifier() TagModifier {
+	d.params.Reset()
+	return d
+}
+
+func (d *tag) SetName(name string) TagModifier {
+	d.params.Set(NameKey, name)
+	return d
+}
+
+func (d *tag) SetDefinition(definition string) TagModifier {
+	d.params.Set(DefinitionKey, definition)
+	return d
+}
+
+func (d *tag) SetComment(comment string) TagModifier {
+	d.params.Set(CommentKey, comment)
+	return d
+}
+
+func (d *tag) SetKernelOpts(kernelOpts string) TagModifier {
+	d.params.Set(KernelOptsKey, kernelOpts)
+	return d
+}
+
+func (d *tag) Update(ctx context.Context) (Tag, error) {
+	res, err := d.client.PutParams(ctx, d.apiPath, d.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	err = unMarshalJson(res, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tag moves to a new path when it is renamed
+	d.apiPath = fmt.Sprintf(TagAPIPathFormat, url.PathEscape(d.name))
+	return d, nil
+}
+
+func (d *tag) Machines(ctx context.Context) ([]Machine, error) {
+	var obj []*machine
+	if err := d.getOperation(ctx, OperationTagMachines, &obj); err != nil {
+		return nil, err
+	}
+
+	ms := &machines{Controller: Controller{client: d.client}}
+	return ms.machineSliceToInterface(obj), nil
+}
+
+func (d *tag) Devices(ctx context.Context) ([]NodeSummary, error) {
+	var nodes []NodeSummary
+	if err := d.getOperation(ctx, OperationTagDevices, &nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+func (d *tag) Controllers(ctx context.Context) ([]NodeSummary, error) {
+	var racks, regions []NodeSummary
+	if err := d.getOperation(ctx, OperationTagRackControllers, &racks); err != nil {
+		return nil, err
+	}
+	if err := d.getOperation(ctx, OperationTagRegionControllers, &regions); err != nil {
+		return nil, err
+	}
+
+	// A region and rack controller is listed by both operations
+	seen := map[string]bool{}
+	var out []NodeSummary
+	for _, node := range append(racks, regions...) {
+		if !seen[node.SystemID] {
+			seen[node.SystemID] = true
+			out = append(out, node)
+		}
+	}
+
+	return out, nil
+}
+
+func (d *tag) Rebuild(ctx context.Context) error {
+	params := url.Values{}
+	params.Set(Operation, OperationRebuild)
+
+	res, err := d.client.Post(ctx, d.apiPath, params)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (d *tag) UpdateNodes(ctx context.Context, add, remove []string) (*TagNodesUpdate, error) {
+	params := url.Values{}
+	params.Set(Operation, OperationUpdateNodes)
+	for _, systemID := range add {
+		params.Add(AddKey, systemID)
+	}
+	for _, systemID := range remove {
+		params.Add(RemoveKey, systemID)
+	}
+
+	res, err := d.client.Post(ctx, d.apiPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var update *TagNodesUpdate
+	err = unMarshalJson(res, &update)
+	if err != nil {
+		return nil, err
+	}
+
+	return update, nil
+}
+
+func (d *tag) getOperation(ctx context.Context, op string, v interface{}) error {
+	params := url.Values{}
+	params.Set(Operation, op)
+
+	res, err := d.client.Get(ctx, d.apiPath, params)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, v)
+}
+
 func (d *tag) Name() string {
 	return d.name
 }
@@ -156,7 +445,7 @@ func (d *tag) UnmarshalJSON(data []byte) error {
 	}
 
 	d.name = des.Name
-	d.comment = des.KernelOpts
+	d.comment = des.Comment
 	d.resource_uri = des.ResourceUri
 	d.kernel_opts = des.KernelOpts
 	d.definition = des.Definition
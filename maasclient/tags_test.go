@@ -28,12 +28,15 @@ package maasclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTags(t *testing.T) {
@@ -140,3 +143,102 @@ func TestTags(t *testing.T) {
 		fmt.Printf("✅ Verified tag '%s' is removed from machine %s\n", tagName, systemID)
 	})
 }
+
+func TestTag_Unmarshal(t *testing.T) {
+	data := `{"name": "nvme", "definition": "//node[@class='storage']", "comment": "NVMe machines", "kernel_opts": "nvme_core.io_timeout=255"}`
+
+	var tg *tag
+	err := json.Unmarshal([]byte(data), &tg)
+	assert.Nil(t, err)
+	assert.Equal(t, tg.Comment(), "NVMe machines")
+	assert.Equal(t, tg.KernelOpts(), "nvme_core.io_timeout=255")
+	assert.Equal(t, tg.Definition(), "//node[@class='storage']")
+}
+
+func TestTag_UpdateNodes(t *testing.T) {
+	client := newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			switch req.params.Get(Operation) {
+			case OperationTagRackControllers:
+				return stubResponse(http.StatusOK, `[{"system_id": "ctrl01", "hostname": "maas"}]`)
+			case OperationTagRegionControllers:
+				return stubResponse(http.StatusOK, `[{"system_id": "ctrl01", "hostname": "maas"}, {"system_id": "ctrl02", "hostname": "region"}]`)
+			}
+			return stubResponse(http.StatusOK, `[]`)
+		},
+		http.MethodPost: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, fmt.Sprintf(`{"added": %d, "removed": %d}`, len(req.params[AddKey]), len(req.params[RemoveKey])))
+		},
+	})
+	tg := tagStructToInterface(&tag{name: "rack 7"}, client)
+
+	var add []string
+	for i := 0; i < 200; i++ {
+		add = append(add, fmt.Sprintf("node%03d", i))
+	}
+
+	res, err := tg.UpdateNodes(context.Background(), add, []string{"old001", "old002"})
+	assert.Nil(t, err)
+	assert.Equal(t, res.Added, 200)
+	assert.Equal(t, res.Removed, 2)
+	posts := client.sent(http.MethodPost)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, posts[0].path, "/tags/rack%207/")
+	assert.Equal(t, posts[0].params.Get(Operation), OperationUpdateNodes)
+
+	controllers, err := tg.Controllers(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, controllers, 2)
+}
+
+func TestTags_Definitions(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with an unused tag name
+	name := "REPLACE_WITH_TEST_TAG_NAME"
+
+	t.Run("tag without name", func(t *testing.T) {
+		res, err := c.Tags().Builder().WithComment("no name").Create(ctx)
+		assert.NotNil(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("create, update, rebuild and delete tag", func(t *testing.T) {
+		if name == "REPLACE_WITH_TEST_TAG_NAME" {
+			t.Skip("Please replace placeholder tag name")
+			return
+		}
+
+		res, err := c.Tags().Builder().
+			WithName(name).
+			WithDefinition("//node[@class='system']").
+			WithComment("created by tests").
+			WithKernelOpts("console=ttyS0").
+			Create(ctx)
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, res.Comment(), "created by tests")
+
+		res, err = res.Modifier().SetComment("updated").SetName(name + "-renamed").Update(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, res.Name(), name+"-renamed")
+
+		res, err = c.Tags().Tag(name + "-renamed").Get(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, res.KernelOpts(), "console=ttyS0")
+
+		assert.Nil(t, res.Rebuild(ctx))
+
+		_, err = res.Machines(ctx)
+		assert.Nil(t, err)
+		_, err = res.Devices(ctx)
+		assert.Nil(t, err)
+		_, err = res.Controllers(ctx)
+		assert.Nil(t, err)
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
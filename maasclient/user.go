@@ -19,31 +19,118 @@ package maasclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
 )
 
 const (
-	UsersAPIPath = "/users/"
+	UsersAPIPath      = "/users/"
+	UserAPIPathFormat = "/users/%s/"
 )
 
 type Users interface {
 	List(ctx context.Context) ([]User, error)
 	WhoAmI(ctx context.Context) (User, error)
+	// User returns the user with the given username, MAAS addresses users by username
+	User(username string) User
+	// Builder returns a builder for a new user, creating users requires an administrator
+	Builder() UserBuilder
 }
 
 type User interface {
+	Get(ctx context.Context) (User, error)
+	// Delete deletes the user. Machines, IP addresses and other resources the user owns are
+	// given to transferResourcesTo, MAAS refuses to delete a user owning resources otherwise.
+	Delete(ctx context.Context, transferResourcesTo string) error
+	// MachineCount returns the number of machines owned by the user
+	MachineCount(ctx context.Context) (int, error)
+	// SSHKeyCount returns the number of SSH keys of the user. MAAS only lists the keys of
+	// the authenticated user, so this fails for any other user.
+	SSHKeyCount(ctx context.Context) (int, error)
 	IsSuperUser() bool
 	UserName() string
 	IsLocal() bool
 	Email() string
 }
 
+type UserBuilder interface {
+	WithUsername(username string) UserBuilder
+	WithEmail(email string) UserBuilder
+	WithPassword(password string) UserBuilder
+	WithSuperUser(superuser bool) UserBuilder
+	Create(ctx context.Context) (User, error)
+}
+
 type user struct {
+	Controller
 	superuser bool
 	local     bool
 	username  string
 	email     string
 }
 
+func (u *user) Get(ctx context.Context) (User, error) {
+	res, err := u.client.Get(ctx, u.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return u, unMarshalJson(res, &u)
+}
+
+func (u *user) Delete(ctx context.Context, transferResourcesTo string) error {
+	// MAAS reads the parameters of a DELETE from the query string
+	path := u.apiPath
+	if transferResourcesTo != "" {
+		params := url.Values{}
+		params.Set(TransferResourcesToKey, transferResourcesTo)
+		path += "?" + params.Encode()
+	}
+
+	res, err := u.client.Delete(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (u *user) MachineCount(ctx context.Context) (int, error) {
+	params := url.Values{}
+	params.Set(OwnerKey, u.username)
+
+	res, err := u.client.Get(ctx, "/machines/", params)
+	if err != nil {
+		return 0, err
+	}
+
+	var machines []json.RawMessage
+	err = unMarshalJson(res, &machines)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(machines), nil
+}
+
+func (u *user) SSHKeyCount(ctx context.Context) (int, error) {
+	me, err := (&users{Controller: Controller{client: u.client, apiPath: UsersAPIPath, params: ParamsBuilder()}}).WhoAmI(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if me.UserName() != u.username {
+		return 0, fmt.Errorf("cannot count the SSH keys of %s as %s, MAAS only lists the keys of the authenticated user", u.username, me.UserName())
+	}
+
+	keys, err := NewSSHKeysClient(u.client).List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(keys), nil
+}
+
 func (u *user) IsSuperUser() bool {
 	return u.superuser
 }
@@ -82,13 +169,11 @@ func (u *user) UnmarshalJSON(data []byte) error {
 }
 
 type users struct {
-	client  *authenticatedClient
-	apiPath string
-	params  Params
+	Controller
 }
 
 func (u *users) List(ctx context.Context) ([]User, error) {
-	res, err := u.client.Get(ctx, u.apiPath, u.params.Values())
+	res, err := u.client.Get(ctx, u.apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -96,17 +181,79 @@ func (u *users) List(ctx context.Context) ([]User, error) {
 	var obj []*user
 	err = unMarshalJson(res, &obj)
 
-	return userSliceToInterface(obj), err
+	return userSliceToInterface(obj, u.client), err
+}
+
+func (u *users) User(username string) User {
+	return userStructToInterface(&user{username: username}, u.client)
+}
+
+func (u *users) Builder() UserBuilder {
+	u.params.Reset()
+	return u
+}
+
+func (u *users) WithUsername(username string) UserBuilder {
+	u.params.Set(UsernameKey, username)
+	return u
+}
+
+func (u *users) WithEmail(email string) UserBuilder {
+	u.params.Set(EmailKey, email)
+	return u
+}
+
+func (u *users) WithPassword(password string) UserBuilder {
+	u.params.Set(PasswordKey, password)
+	return u
+}
+
+func (u *users) WithSuperUser(superuser bool) UserBuilder {
+	u.params.Set(IsSuperUserKey, strconv.FormatBool(superuser))
+	return u
+}
+
+func (u *users) Create(ctx context.Context) (User, error) {
+	values := u.params.Values()
+	// MAAS requires every parameter, is_superuser included
+	for _, key := range []string{UsernameKey, EmailKey, PasswordKey} {
+		if values.Get(key) == "" {
+			return nil, fmt.Errorf("%s is required to create a user", key)
+		}
+	}
+	if _, ok := values[IsSuperUserKey]; !ok {
+		values.Set(IsSuperUserKey, strconv.FormatBool(false))
+	}
+
+	res, err := u.client.Post(ctx, u.apiPath, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *user
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return userStructToInterface(obj, u.client), nil
 }
 
-func userSliceToInterface(input []*user) []User {
+func userSliceToInterface(input []*user, client Client) []User {
 	var res []User
 	for _, u := range input {
-		res = append(res, u)
+		res = append(res, userStructToInterface(u, client))
 	}
 	return res
 }
 
+func userStructToInterface(in *user, client Client) User {
+	in.client = client
+	in.apiPath = fmt.Sprintf(UserAPIPathFormat, url.PathEscape(in.username))
+	in.params = ParamsBuilder()
+	return in
+}
+
 func (u *users) WhoAmI(ctx context.Context) (User, error) {
 	u.params.Reset()
 	u.params.Set(Operation, OperationWhoAmI)
@@ -118,13 +265,39 @@ func (u *users) WhoAmI(ctx context.Context) (User, error) {
 
 	var obj *user
 	err = unMarshalJson(res, &obj)
-	return obj, err
+	if err != nil {
+		return nil, err
+	}
+
+	return userStructToInterface(obj, u.client), nil
 }
 
 func NewUsersClient(client *authenticatedClient) Users {
 	return &users{
-		client:  client,
-		apiPath: UsersAPIPath,
-		params:  ParamsBuilder(),
+		Controller: Controller{
+			client:  client,
+			apiPath: UsersAPIPath,
+			params:  ParamsBuilder(),
+		},
+	}
+}
+
+// unmarshalUsername decodes a user reference, which MAAS renders either as a
+// username or as a nested user depending on the handler
+func unmarshalUsername(data json.RawMessage) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+
+	if data[0] != '{' {
+		var username string
+		err := json.Unmarshal(data, &username)
+		return username, err
+	}
+
+	u := &user{}
+	if err := json.Unmarshal(data, u); err != nil {
+		return "", err
 	}
+	return u.username, nil
 }
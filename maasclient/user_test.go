@@ -18,9 +18,11 @@ package maasclient
 
 import (
 	"context"
-	"github.com/stretchr/testify/assert"
+	"net/http"
 	"os"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestUsers(t *testing.T) {
@@ -44,3 +46,101 @@ func TestUsers(t *testing.T) {
 	})
 
 }
+
+func TestUsers_Manage(t *testing.T) {
+	client := newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			switch {
+			case req.params.Get(Operation) == OperationWhoAmI:
+				return stubResponse(http.StatusOK, `{"username": "admin", "is_superuser": true}`)
+			case req.path == "/machines/":
+				return stubResponse(http.StatusOK, `[{"system_id": "abc123"}, {"system_id": "def456"}]`)
+			case req.path == SSHKeysAPIPath:
+				return stubResponse(http.StatusOK, `[{"id": 1, "key": "ssh-ed25519 AAAA"}]`)
+			}
+			return stubResponse(http.StatusOK, `{"username": "jdoe", "email": "jdoe@example.com", "is_local": true}`)
+		},
+		http.MethodPost: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"username": "`+req.params.Get(UsernameKey)+`", "email": "`+req.params.Get(EmailKey)+`"}`)
+		},
+		http.MethodDelete: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusNoContent, "")
+		},
+	})
+	us := &users{Controller: Controller{client: client, apiPath: UsersAPIPath, params: ParamsBuilder()}}
+	ctx := context.Background()
+
+	t.Run("create requires email and password", func(t *testing.T) {
+		_, err := us.Builder().WithUsername("jdoe").WithEmail("jdoe@example.com").Create(ctx)
+		assert.NotNil(t, err)
+		assert.Empty(t, client.sent(http.MethodPost))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		u, err := us.Builder().
+			WithUsername("jdoe").
+			WithEmail("jdoe@example.com").
+			WithPassword("s3cret").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, u.UserName(), "jdoe")
+		posts := client.sent(http.MethodPost)
+		assert.Len(t, posts, 1)
+		assert.Equal(t, posts[0].params.Get(IsSuperUserKey), "false")
+	})
+
+	t.Run("delete with transfer", func(t *testing.T) {
+		err := us.User("j doe").Delete(ctx, "ops")
+		assert.Nil(t, err)
+		assert.Equal(t, client.last().path, "/users/j%20doe/?transfer_resources_to=ops")
+
+		err = us.User("jdoe").Delete(ctx, "")
+		assert.Nil(t, err)
+		assert.Equal(t, client.last().path, "/users/jdoe/")
+	})
+
+	t.Run("counts", func(t *testing.T) {
+		count, err := us.User("jdoe").MachineCount(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, count, 2)
+		assert.Equal(t, client.last().params.Get(OwnerKey), "jdoe")
+
+		_, err = us.User("jdoe").SSHKeyCount(ctx)
+		assert.NotNil(t, err)
+
+		count, err = us.User("admin").SSHKeyCount(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, count, 1)
+	})
+}
+
+func TestUsers_Lifecycle(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	transferTo := "REPLACE_WITH_USERNAME"
+	if transferTo == "REPLACE_WITH_USERNAME" {
+		t.Skip("Skipping test - please replace REPLACE_WITH_USERNAME with a user to transfer resources to")
+	}
+
+	u, err := c.Users().Builder().
+		WithUsername("testcase-user").
+		WithEmail("testcase-user@example.com").
+		WithPassword("testcase-password").
+		Create(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, u.UserName(), "testcase-user")
+
+	u, err = c.Users().User("testcase-user").Get(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, u.Email(), "testcase-user@example.com")
+	assert.False(t, u.IsSuperUser())
+
+	count, err := u.MachineCount(ctx)
+	assert.Nil(t, err)
+	assert.Zero(t, count)
+
+	err = u.Delete(ctx, transferTo)
+	assert.Nil(t, err)
+}
@@ -16,55 +16,336 @@ limitations under the License.
 
 package maasclient
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
 
+const (
+	VLANsAPIPathFormat = "/fabrics/%d/vlans/"
+	VLANAPIPathFormat  = "/fabrics/%d/vlans/%d/"
+)
+
+// VLANs manages the VLANs of a fabric
+type VLANs interface {
+	List(ctx context.Context) ([]VLAN, error)
+	// VLAN returns the VLAN with the given VID, MAAS addresses VLANs by fabric and VID
+	VLAN(vid int) VLAN
+	Builder() VLANBuilder
+}
+
+// VLAN is the VLAN as reported by MAAS, both by the VLANs controller and
+// embedded in subnets, fabrics and network interfaces
 type VLAN interface {
+	Get(ctx context.Context) (VLAN, error)
+	Delete(ctx context.Context) error
+	Modifier() VLANModifier
+	ID() int
+	Name() string
+	Description() string
 	VID() int
 	MTU() int
 	IsDHCPOn() bool
+	// ExternalDHCP returns the address of a DHCP server MAAS detected on the VLAN, if any
+	ExternalDHCP() string
 	FabricID() int
 	FabricName() string
+	// Space returns the name of the space, "undefined" when the VLAN isn't in one
+	Space() string
+	// PrimaryRack returns the system ID of the rack controller serving DHCP, if any
+	PrimaryRack() string
+	// SecondaryRack returns the system ID of the standby rack controller, if any
+	SecondaryRack() string
+	// RelayVLANID returns the ID of the VLAN DHCP is relayed to, or 0 when not relayed
+	RelayVLANID() int
+}
+
+type VLANBuilder interface {
+	// WithVID sets the VID, it is required
+	WithVID(vid int) VLANBuilder
+	WithName(name string) VLANBuilder
+	WithDescription(description string) VLANBuilder
+	WithMTU(mtu int) VLANBuilder
+	WithSpace(space string) VLANBuilder
+	Create(ctx context.Context) (VLAN, error)
+}
+
+// VLANModifier changes a VLAN, settings that aren't set are left unchanged. Enabling DHCP
+// requires a primary rack controller, either already set or set in the same update.
+type VLANModifier interface {
+	SetName(name string) VLANModifier
+	SetDescription(description string) VLANModifier
+	SetVID(vid int) VLANModifier
+	SetMTU(mtu int) VLANModifier
+	// SetSpace sets the space by name or ID, an empty string removes the VLAN from its space
+	SetSpace(space string) VLANModifier
+	SetDHCPOn(dhcpOn bool) VLANModifier
+	// SetPrimaryRack sets the system ID of the rack controller serving DHCP
+	SetPrimaryRack(systemID string) VLANModifier
+	// SetSecondaryRack sets the system ID of the standby rack controller, an empty string removes it
+	SetSecondaryRack(systemID string) VLANModifier
+	// SetRelayVLAN sets the ID of the VLAN to relay DHCP to, 0 stops relaying
+	SetRelayVLAN(vlanID int) VLANModifier
+	Update(ctx context.Context) (VLAN, error)
+}
+
+type vlans struct {
+	Controller
+	fabricID int
+}
+
+func (vs *vlans) List(ctx context.Context) ([]VLAN, error) {
+	res, err := vs.client.Get(ctx, vs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*vlan
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return vlanStructSliceToInterface(obj, vs.client), nil
+}
+
+func (vs *vlans) VLAN(vid int) VLAN {
+	return vlanStructToInterface(&vlan{fabricID: vs.fabricID, vid: vid}, vs.client)
+}
+
+func (vs *vlans) Builder() VLANBuilder {
+	vs.params.Reset()
+	return vs
+}
+
+func (vs *vlans) WithVID(vid int) VLANBuilder {
+	vs.params.Set(VIDKey, strconv.Itoa(vid))
+	return vs
+}
+
+func (vs *vlans) WithName(name string) VLANBuilder {
+	vs.params.Set(NameKey, name)
+	return vs
+}
+
+func (vs *vlans) WithDescription(description string) VLANBuilder {
+	vs.params.Set(DescriptionKey, description)
+	return vs
+}
+
+func (vs *vlans) WithMTU(mtu int) VLANBuilder {
+	vs.params.Set(MTUKey, strconv.Itoa(mtu))
+	return vs
+}
+
+func (vs *vlans) WithSpace(space string) VLANBuilder {
+	vs.params.Set(SpaceKey, space)
+	return vs
+}
+
+func (vs *vlans) Create(ctx context.Context) (VLAN, error) {
+	res, err := vs.client.Post(ctx, vs.apiPath, vs.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *vlan
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return vlanStructToInterface(obj, vs.client), nil
+}
+
+func vlanStructSliceToInterface(in []*vlan, client Client) []VLAN {
+	var out []VLAN
+	for _, v := range in {
+		out = append(out, vlanStructToInterface(v, client))
+	}
+	return out
+}
+
+func vlanStructToInterface(in *vlan, client Client) VLAN {
+	in.client = client
+	in.apiPath = fmt.Sprintf(VLANAPIPathFormat, in.fabricID, in.vid)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type vlan struct {
+	Controller
+	id            int
+	vid           int
+	name          string
+	description   string
+	fabricID      int
+	fabricName    string
+	mtu           int
+	dhcpOn        bool
+	externalDHCP  string
+	space         string
+	primaryRack   string
+	secondaryRack string
+	relayVLANID   int
+}
+
+func (v *vlan) Get(ctx context.Context) (VLAN, error) {
+	res, err := v.client.Get(ctx, v.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, unMarshalJson(res, &v)
+}
+
+func (v *vlan) Delete(ctx context.Context) error {
+	res, err := v.client.Delete(ctx, v.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (v *vlan) Modifier() VLANModifier {
+	v.params.Reset()
+	return v
+}
+
+func (v *vlan) SetName(name string) VLANModifier {
+	v.params.Set(NameKey, name)
+	return v
+}
+
+func (v *vlan) SetDescription(description string) VLANModifier {
+	v.params.Set(DescriptionKey, description)
+	return v
+}
+
+func (v *vlan) SetVID(vid int) VLANModifier {
+	v.params.Set(VIDKey, strconv.Itoa(vid))
+	return v
+}
+
+func (v *vlan) SetMTU(mtu int) VLANModifier {
+	v.params.Set(MTUKey, strconv.Itoa(mtu))
+	return v
+}
+
+func (v *vlan) SetSpace(space string) VLANModifier {
+	v.params.Set(SpaceKey, space)
+	return v
+}
+
+func (v *vlan) SetDHCPOn(dhcpOn bool) VLANModifier {
+	v.params.Set(DHCPOnKey, strconv.FormatBool(dhcpOn))
+	return v
+}
+
+func (v *vlan) SetPrimaryRack(systemID string) VLANModifier {
+	v.params.Set(PrimaryRackKey, systemID)
+	return v
+}
+
+func (v *vlan) SetSecondaryRack(systemID string) VLANModifier {
+	v.params.Set(SecondaryRackKey, systemID)
+	return v
+}
+
+func (v *vlan) SetRelayVLAN(vlanID int) VLANModifier {
+	relay := ""
+	if vlanID > 0 {
+		relay = strconv.Itoa(vlanID)
+	}
+	v.params.Set(RelayVLANKey, relay)
+	return v
+}
+
+func (v *vlan) Update(ctx context.Context) (VLAN, error) {
+	res, err := v.client.PutParams(ctx, v.apiPath, v.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	err = unMarshalJson(res, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new VID changes the path of the VLAN
+	return vlanStructToInterface(v, v.client), nil
+}
+
+func (v *vlan) ID() int {
+	return v.id
 }
 
-type vLAN struct {
-	vid        int
-	mtu        int
-	dhcpOn     bool
-	fabricId   int
-	fabricName string
-	id         int
-	name       string
+func (v *vlan) Name() string {
+	return v.name
 }
 
-func (v *vLAN) VID() int {
+func (v *vlan) Description() string {
+	return v.description
+}
+
+func (v *vlan) VID() int {
 	return v.vid
 }
 
-func (v *vLAN) MTU() int {
+func (v *vlan) MTU() int {
 	return v.mtu
 }
 
-func (v *vLAN) IsDHCPOn() bool {
+func (v *vlan) IsDHCPOn() bool {
 	return v.dhcpOn
 }
 
-func (v *vLAN) FabricID() int {
-	return v.fabricId
+func (v *vlan) ExternalDHCP() string {
+	return v.externalDHCP
+}
+
+func (v *vlan) FabricID() int {
+	return v.fabricID
 }
 
-func (v *vLAN) FabricName() string {
+func (v *vlan) FabricName() string {
 	return v.fabricName
 }
 
-func (v *vLAN) UnmarshalJSON(data []byte) error {
+func (v *vlan) Space() string {
+	return v.space
+}
+
+func (v *vlan) PrimaryRack() string {
+	return v.primaryRack
+}
+
+func (v *vlan) SecondaryRack() string {
+	return v.secondaryRack
+}
+
+func (v *vlan) RelayVLANID() int {
+	return v.relayVLANID
+}
+
+func (v *vlan) UnmarshalJSON(data []byte) error {
 	des := &struct {
-		Id       int    `json:"id"`
-		VId      int    `json:"vid"`
-		Name     string `json:"name"`
-		Fabric   string `json:"fabric"`
-		FabricID int    `json:"fabric_id"`
-		MTU      int    `json:"mtu"`
-		DHCPOn   bool   `json:"dhcp_on"`
+		ID            int             `json:"id"`
+		VID           int             `json:"vid"`
+		Name          string          `json:"name"`
+		Description   string          `json:"description"`
+		Fabric        string          `json:"fabric"`
+		FabricID      int             `json:"fabric_id"`
+		MTU           int             `json:"mtu"`
+		DHCPOn        bool            `json:"dhcp_on"`
+		ExternalDHCP  *string         `json:"external_dhcp"`
+		Space         string          `json:"space"`
+		PrimaryRack   *string         `json:"primary_rack"`
+		SecondaryRack *string         `json:"secondary_rack"`
+		RelayVLAN     json.RawMessage `json:"relay_vlan"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -72,13 +353,53 @@ func (v *vLAN) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	v.id = des.Id
-	v.vid = des.VId
+	v.id = des.ID
+	v.vid = des.VID
 	v.name = des.Name
-	v.fabricId = des.FabricID
+	v.description = des.Description
+	v.fabricID = des.FabricID
 	v.fabricName = des.Fabric
 	v.mtu = des.MTU
 	v.dhcpOn = des.DHCPOn
+	v.externalDHCP = stringOrEmpty(des.ExternalDHCP)
+	v.space = des.Space
+	v.primaryRack = stringOrEmpty(des.PrimaryRack)
+	v.secondaryRack = stringOrEmpty(des.SecondaryRack)
+
+	// Depending on the MAAS version relay_vlan is null, an ID or a nested VLAN
+	v.relayVLANID = 0
+	if len(des.RelayVLAN) > 0 && string(des.RelayVLAN) != "null" {
+		relay := &struct {
+			ID int `json:"id"`
+		}{}
+		if des.RelayVLAN[0] == '{' {
+			err = json.Unmarshal(des.RelayVLAN, relay)
+		} else {
+			err = json.Unmarshal(des.RelayVLAN, &relay.ID)
+		}
+		if err != nil {
+			return err
+		}
+		v.relayVLANID = relay.ID
+	}
 
 	return nil
 }
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func NewVLANsClient(client Client, fabricID int) VLANs {
+	return &vlans{
+		Controller: Controller{
+			client:  client,
+			apiPath: fmt.Sprintf(VLANsAPIPathFormat, fabricID),
+			params:  ParamsBuilder(),
+		},
+		fabricID: fabricID,
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVLAN_Unmarshal(t *testing.T) {
+	t.Run("relay vlan as object", func(t *testing.T) {
+		data := `{
+			"id": 5002, "vid": 100, "name": "storage", "fabric": "fabric-0", "fabric_id": 0,
+			"mtu": 9000, "dhcp_on": true, "external_dhcp": null, "space": "storage",
+			"primary_rack": "4y3h7n", "secondary_rack": null,
+			"relay_vlan": {"id": 5001, "vid": 0}
+		}`
+
+		var v *vlan
+		err := json.Unmarshal([]byte(data), &v)
+		assert.Nil(t, err)
+		assert.Equal(t, v.ID(), 5002)
+		assert.Equal(t, v.VID(), 100)
+		assert.Equal(t, v.Space(), "storage")
+		assert.Equal(t, v.PrimaryRack(), "4y3h7n")
+		assert.Empty(t, v.SecondaryRack())
+		assert.Empty(t, v.ExternalDHCP())
+		assert.Equal(t, v.RelayVLANID(), 5001)
+	})
+
+	t.Run("relay vlan as id", func(t *testing.T) {
+		var v *vlan
+		err := json.Unmarshal([]byte(`{"id": 5002, "relay_vlan": 5001}`), &v)
+		assert.Nil(t, err)
+		assert.Equal(t, v.RelayVLANID(), 5001)
+	})
+
+	t.Run("no relay vlan", func(t *testing.T) {
+		var v *vlan
+		err := json.Unmarshal([]byte(`{"id": 5002, "relay_vlan": null}`), &v)
+		assert.Nil(t, err)
+		assert.Zero(t, v.RelayVLANID())
+	})
+}
+
+func TestVLANs(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	t.Run("create, update and delete vlan", func(t *testing.T) {
+		res, err := c.VLANs(0).Builder().WithVID(3999).WithName("vlan-test").Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.VID(), 3999)
+
+		res, err = res.Modifier().SetMTU(9000).Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.MTU(), 9000)
+
+		list, err := c.VLANs(0).List(ctx)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, list)
+
+		err = c.VLANs(0).VLAN(3999).Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
+
+func TestVLAN_Modifier(t *testing.T) {
+	client := newStubClient(map[string]stubHandler{
+		http.MethodPut: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 5002, "vid": 200, "fabric_id": 1, "relay_vlan": null}`)
+		},
+		http.MethodDelete: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusNoContent, "")
+		},
+	})
+	ctx := context.Background()
+
+	res, err := NewVLANsClient(client, 1).VLAN(100).Modifier().SetVID(200).SetSpace("").SetRelayVLAN(0).Update(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, client.last().path, "/fabrics/1/vlans/100/")
+	assert.Equal(t, client.last().params, url.Values{VIDKey: {"200"}, SpaceKey: {""}, RelayVLANKey: {""}})
+
+	// The VLAN is addressed by its new VID afterwards
+	assert.Nil(t, res.Delete(ctx))
+	assert.Equal(t, client.last().path, "/fabrics/1/vlans/200/")
+}
+
+func TestVLAN_EmbeddedHandles(t *testing.T) {
+	client := newStubClient(map[string]stubHandler{
+		http.MethodGet: func(req stubRequest) *http.Response {
+			return stubResponse(http.StatusOK, `{"id": 3, "vlans": [{"id": 5003, "vid": 0, "fabric_id": 3}]}`)
+		},
+	})
+
+	f, err := fabricStructToInterface(&fabric{id: 3}, client).Get(context.Background())
+	assert.Nil(t, err)
+	if assert.Len(t, f.VLANs(), 1) {
+		_, err = f.VLANs()[0].Get(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, client.last().path, "/fabrics/3/vlans/0/")
+	}
+}
@@ -291,14 +291,14 @@ func (c *vmHost) Zone() Zone {
 	if c.data.Zone.Name == "" || c.data.Zone.ID == 0 {
 		return nil
 	}
-	return &zone{name: c.data.Zone.Name, id: c.data.Zone.ID}
+	return zoneStructToInterface(&zone{name: c.data.Zone.Name, id: c.data.Zone.ID}, c.client)
 }
 
 func (c *vmHost) ResourcePool() ResourcePool {
 	if c.data.Pool.Name == "" || c.data.Pool.ID <= 0 {
 		return nil
 	}
-	return &resourcePool{name: c.data.Pool.Name, id: c.data.Pool.ID}
+	return resourcePoolStructToInterface(&resourcePool{name: c.data.Pool.Name, id: c.data.Pool.ID}, c.client)
 }
 
 // VMHost property implementations - these are populated from API response data
@@ -0,0 +1,342 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	VolumeGroupsAPIPathFormat = "/nodes/%s/volume-groups/"
+	VolumeGroupAPIPathFormat  = "/nodes/%s/volume-group/%d/"
+)
+
+// VolumeGroups manages the LVM volume groups of a machine
+type VolumeGroups interface {
+	List(ctx context.Context) ([]VolumeGroup, error)
+	VolumeGroup(id int) VolumeGroup
+	Builder() VolumeGroupBuilder
+}
+
+// VolumeGroup represents a single LVM volume group on a machine
+type VolumeGroup interface {
+	Get(ctx context.Context) (VolumeGroup, error)
+	Delete(ctx context.Context) error
+	Modifier() VolumeGroupModifier
+	// CreateLogicalVolume creates a logical volume of size bytes and returns its block device
+	CreateLogicalVolume(ctx context.Context, name string, size int64) (BlockDevice, error)
+	DeleteLogicalVolume(ctx context.Context, id int) error
+	ID() int
+	UUID() string
+	Name() string
+	SystemID() string
+	// Size returns the total size of the volume group in bytes
+	Size() int64
+	// UsedSize returns the bytes allocated to logical volumes
+	UsedSize() int64
+	// AvailableSize returns the bytes still free for new logical volumes
+	AvailableSize() int64
+	// Devices returns the block devices and partitions backing the volume group
+	Devices() []BlockDevice
+	LogicalVolumes() []BlockDevice
+}
+
+type VolumeGroupBuilder interface {
+	WithName(name string) VolumeGroupBuilder
+	WithUUID(uuid string) VolumeGroupBuilder
+	WithBlockDevices(ids []int) VolumeGroupBuilder
+	WithPartitions(ids []int) VolumeGroupBuilder
+	Create(ctx context.Context) (VolumeGroup, error)
+}
+
+type VolumeGroupModifier interface {
+	SetName(name string) VolumeGroupModifier
+	SetUUID(uuid string) VolumeGroupModifier
+	AddBlockDevices(ids []int) VolumeGroupModifier
+	RemoveBlockDevices(ids []int) VolumeGroupModifier
+	AddPartitions(ids []int) VolumeGroupModifier
+	RemovePartitions(ids []int) VolumeGroupModifier
+	Update(ctx context.Context) (VolumeGroup, error)
+}
+
+type volumeGroups struct {
+	Controller
+	systemID string
+}
+
+func (vgs *volumeGroups) List(ctx context.Context) ([]VolumeGroup, error) {
+	res, err := vgs.client.Get(ctx, vgs.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []*volumeGroup
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return volumeGroupStructSliceToInterface(obj, vgs.client), nil
+}
+
+func (vgs *volumeGroups) VolumeGroup(id int) VolumeGroup {
+	return volumeGroupStructToInterface(&volumeGroup{id: id, systemID: vgs.systemID}, vgs.client)
+}
+
+func (vgs *volumeGroups) Builder() VolumeGroupBuilder {
+	vgs.params.Reset()
+	return vgs
+}
+
+func (vgs *volumeGroups) WithName(name string) VolumeGroupBuilder {
+	vgs.params.Set(NameKey, name)
+	return vgs
+}
+
+func (vgs *volumeGroups) WithUUID(uuid string) VolumeGroupBuilder {
+	vgs.params.Set(UUIDKey, uuid)
+	return vgs
+}
+
+func (vgs *volumeGroups) WithBlockDevices(ids []int) VolumeGroupBuilder {
+	addIntParams(vgs.params, BlockDevicesKey, ids)
+	return vgs
+}
+
+func (vgs *volumeGroups) WithPartitions(ids []int) VolumeGroupBuilder {
+	addIntParams(vgs.params, PartitionsKey, ids)
+	return vgs
+}
+
+func (vgs *volumeGroups) Create(ctx context.Context) (VolumeGroup, error) {
+	res, err := vgs.client.Post(ctx, vgs.apiPath, vgs.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *volumeGroup
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return volumeGroupStructToInterface(obj, vgs.client), nil
+}
+
+func volumeGroupStructSliceToInterface(in []*volumeGroup, client Client) []VolumeGroup {
+	var out []VolumeGroup
+	for _, vg := range in {
+		out = append(out, volumeGroupStructToInterface(vg, client))
+	}
+	return out
+}
+
+func volumeGroupStructToInterface(in *volumeGroup, client Client) VolumeGroup {
+	in.client = client
+	in.apiPath = fmt.Sprintf(VolumeGroupAPIPathFormat, in.systemID, in.id)
+	in.params = ParamsBuilder()
+	return in
+}
+
+type volumeGroup struct {
+	Controller
+	id             int
+	uuid           string
+	name           string
+	systemID       string
+	size           int64
+	usedSize       int64
+	availableSize  int64
+	devices        []*blockDevice
+	logicalVolumes []*blockDevice
+}
+
+func (vg *volumeGroup) Get(ctx context.Context) (VolumeGroup, error) {
+	res, err := vg.client.Get(ctx, vg.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return vg, unMarshalJson(res, &vg)
+}
+
+func (vg *volumeGroup) Delete(ctx context.Context) error {
+	res, err := vg.client.Delete(ctx, vg.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (vg *volumeGroup) CreateLogicalVolume(ctx context.Context, name string, size int64) (BlockDevice, error) {
+	vg.params.Reset()
+	vg.params.Set(Operation, OperationCreateLogicalVolume)
+	vg.params.Set(NameKey, name)
+	vg.params.Set(SizeKey, strconv.FormatInt(size, 10))
+
+	res, err := vg.client.Post(ctx, vg.apiPath, vg.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *blockDevice
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockDeviceToInterface(obj), nil
+}
+
+func (vg *volumeGroup) DeleteLogicalVolume(ctx context.Context, id int) error {
+	vg.params.Reset()
+	vg.params.Set(Operation, OperationDeleteLogicalVolume)
+	vg.params.Set(IDKey, strconv.Itoa(id))
+
+	res, err := vg.client.Post(ctx, vg.apiPath, vg.params.Values())
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (vg *volumeGroup) Modifier() VolumeGroupModifier {
+	vg.params.Reset()
+	return vg
+}
+
+func (vg *volumeGroup) SetName(name string) VolumeGroupModifier {
+	vg.params.Set(NameKey, name)
+	return vg
+}
+
+func (vg *volumeGroup) SetUUID(uuid string) VolumeGroupModifier {
+	vg.params.Set(UUIDKey, uuid)
+	return vg
+}
+
+func (vg *volumeGroup) AddBlockDevices(ids []int) VolumeGroupModifier {
+	addIntParams(vg.params, AddBlockDevicesKey, ids)
+	return vg
+}
+
+func (vg *volumeGroup) RemoveBlockDevices(ids []int) VolumeGroupModifier {
+	addIntParams(vg.params, RemoveBlockDevicesKey, ids)
+	return vg
+}
+
+func (vg *volumeGroup) AddPartitions(ids []int) VolumeGroupModifier {
+	addIntParams(vg.params, AddPartitionsKey, ids)
+	return vg
+}
+
+func (vg *volumeGroup) RemovePartitions(ids []int) VolumeGroupModifier {
+	addIntParams(vg.params, RemovePartitionsKey, ids)
+	return vg
+}
+
+func (vg *volumeGroup) Update(ctx context.Context) (VolumeGroup, error) {
+	res, err := vg.client.PutParams(ctx, vg.apiPath, vg.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return vg, unMarshalJson(res, &vg)
+}
+
+func (vg *volumeGroup) ID() int {
+	return vg.id
+}
+
+func (vg *volumeGroup) UUID() string {
+	return vg.uuid
+}
+
+func (vg *volumeGroup) Name() string {
+	return vg.name
+}
+
+func (vg *volumeGroup) SystemID() string {
+	return vg.systemID
+}
+
+func (vg *volumeGroup) Size() int64 {
+	return vg.size
+}
+
+func (vg *volumeGroup) UsedSize() int64 {
+	return vg.usedSize
+}
+
+func (vg *volumeGroup) AvailableSize() int64 {
+	return vg.availableSize
+}
+
+func (vg *volumeGroup) Devices() []BlockDevice {
+	return blockDeviceSliceToInterface(vg.devices)
+}
+
+func (vg *volumeGroup) LogicalVolumes() []BlockDevice {
+	return blockDeviceSliceToInterface(vg.logicalVolumes)
+}
+
+func (vg *volumeGroup) UnmarshalJSON(data []byte) error {
+	des := &struct {
+		ID             int            `json:"id"`
+		UUID           string         `json:"uuid"`
+		Name           string         `json:"name"`
+		SystemID       string         `json:"system_id"`
+		Size           int64          `json:"size"`
+		UsedSize       int64          `json:"used_size"`
+		AvailableSize  int64          `json:"available_size"`
+		Devices        []*blockDevice `json:"devices"`
+		LogicalVolumes []*blockDevice `json:"logical_volumes"`
+	}{}
+
+	err := json.Unmarshal(data, des)
+	if err != nil {
+		return err
+	}
+
+	vg.id = des.ID
+	vg.uuid = des.UUID
+	vg.name = des.Name
+	vg.systemID = des.SystemID
+	vg.size = des.Size
+	vg.usedSize = des.UsedSize
+	vg.availableSize = des.AvailableSize
+	vg.devices = des.Devices
+	vg.logicalVolumes = des.LogicalVolumes
+
+	return nil
+}
+
+func newVolumeGroupsClient(client Client, systemID string) VolumeGroups {
+	return &volumeGroups{
+		Controller: Controller{
+			client:  client,
+			apiPath: fmt.Sprintf(VolumeGroupsAPIPathFormat, systemID),
+			params:  ParamsBuilder(),
+		},
+		systemID: systemID,
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 Spectro Cloud
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maasclient
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeGroups(t *testing.T) {
+	c := NewAuthenticatedClientSet(os.Getenv("MAAS_ENDPOINT"), os.Getenv("MAAS_API_KEY"))
+
+	ctx := context.Background()
+
+	// TODO: Replace with a Ready machine and an unused block device on it
+	systemID := "REPLACE_WITH_MACHINE_SYSTEM_ID"
+	blockDeviceID := 0
+
+	t.Run("create vg with logical volume", func(t *testing.T) {
+		if systemID == "REPLACE_WITH_MACHINE_SYSTEM_ID" {
+			t.Skip("Please replace placeholder machine system ID and block device ID")
+			return
+		}
+
+		vgs := c.Machines().Machine(systemID).VolumeGroups()
+
+		vg, err := vgs.Builder().
+			WithName("vg-test").
+			WithBlockDevices([]int{blockDeviceID}).
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, vg)
+		assert.NotZero(t, vg.Size())
+		assert.Equal(t, vg.Size(), vg.AvailableSize())
+
+		lv, err := vg.CreateLogicalVolume(ctx, "lv-test", 4*1000*1000*1000)
+		assert.Nil(t, err)
+		assert.NotNil(t, lv)
+
+		vg, err = vg.Get(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, vg.LogicalVolumes(), 1)
+		assert.NotZero(t, vg.UsedSize())
+
+		err = vg.DeleteLogicalVolume(ctx, lv.ID())
+		assert.Nil(t, err)
+
+		err = vg.Delete(ctx)
+		assert.Nil(t, err)
+	})
+}
@@ -19,20 +19,48 @@ package maasclient
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
 )
 
 const (
-	ZonesAPIPath = "/zones/"
+	ZonesAPIPath      = "/zones/"
+	ZoneAPIPathFormat = "/zones/%s/"
 )
 
 type Zones interface {
 	List(ctx context.Context) ([]Zone, error)
+	// Zone returns the zone with the given name, MAAS addresses zones by name
+	Zone(name string) Zone
+	// ZoneByID looks up the zone with the given ID
+	ZoneByID(ctx context.Context, id int) (Zone, error)
+	Builder() ZoneBuilder
 }
 
 type Zone interface {
+	Get(ctx context.Context) (Zone, error)
+	Delete(ctx context.Context) error
+	Modifier() ZoneModifier
 	ID() int
 	Name() string
 	Description() string
+	// MachinesCount, DevicesCount and ControllersCount return the number of nodes
+	// in the zone, or 0 when MAAS doesn't report them
+	MachinesCount() int
+	DevicesCount() int
+	ControllersCount() int
+}
+
+type ZoneBuilder interface {
+	WithName(name string) ZoneBuilder
+	WithDescription(description string) ZoneBuilder
+	Create(ctx context.Context) (Zone, error)
+}
+
+type ZoneModifier interface {
+	SetName(name string) ZoneModifier
+	SetDescription(description string) ZoneModifier
+	Update(ctx context.Context) (Zone, error)
 }
 
 type zones struct {
@@ -40,7 +68,7 @@ type zones struct {
 }
 
 func (z *zones) List(ctx context.Context) ([]Zone, error) {
-	res, err := z.client.Get(ctx, z.apiPath, z.params.Values())
+	res, err := z.client.Get(ctx, z.apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +82,55 @@ func (z *zones) List(ctx context.Context) ([]Zone, error) {
 	return zoneStructSliceToInterface(out, z.client), err
 }
 
+func (z *zones) Zone(name string) Zone {
+	return zoneStructToInterface(&zone{name: name}, z.client)
+}
+
+func (z *zones) ZoneByID(ctx context.Context, id int) (Zone, error) {
+	list, err := z.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zn := range list {
+		if zn.ID() == id {
+			return zn, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no zone found with ID %d", id)
+}
+
+func (z *zones) Builder() ZoneBuilder {
+	z.params.Reset()
+	return z
+}
+
+func (z *zones) WithName(name string) ZoneBuilder {
+	z.params.Set(NameKey, name)
+	return z
+}
+
+func (z *zones) WithDescription(description string) ZoneBuilder {
+	z.params.Set(DescriptionKey, description)
+	return z
+}
+
+func (z *zones) Create(ctx context.Context) (Zone, error) {
+	res, err := z.client.Post(ctx, z.apiPath, z.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *zone
+	err = unMarshalJson(res, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return zoneStructToInterface(obj, z.client), nil
+}
+
 func zoneStructSliceToInterface(in []*zone, client Client) []Zone {
 	var out []Zone
 	for _, z := range in {
@@ -63,13 +140,69 @@ func zoneStructSliceToInterface(in []*zone, client Client) []Zone {
 }
 
 func zoneStructToInterface(in *zone, client Client) Zone {
+	in.client = client
+	in.apiPath = fmt.Sprintf(ZoneAPIPathFormat, url.PathEscape(in.name))
+	in.params = ParamsBuilder()
 	return in
 }
 
 type zone struct {
-	id          int
-	name        string
-	description string
+	Controller
+	id               int
+	name             string
+	description      string
+	machinesCount    int
+	devicesCount     int
+	controllersCount int
+}
+
+func (z *zone) Get(ctx context.Context) (Zone, error) {
+	res, err := z.client.Get(ctx, z.apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return z, unMarshalJson(res, &z)
+}
+
+func (z *zone) Delete(ctx context.Context) error {
+	res, err := z.client.Delete(ctx, z.apiPath, nil)
+	if err != nil {
+		return err
+	}
+
+	return unMarshalJson(res, nil)
+}
+
+func (z *zone) Modifier() ZoneModifier {
+	z.params.Reset()
+	return z
+}
+
+func (z *zone) SetName(name string) ZoneModifier {
+	z.params.Set(NameKey, name)
+	return z
+}
+
+func (z *zone) SetDescription(description string) ZoneModifier {
+	z.params.Set(DescriptionKey, description)
+	return z
+}
+
+func (z *zone) Update(ctx context.Context) (Zone, error) {
+	res, err := z.client.PutParams(ctx, z.apiPath, z.params.Values())
+	if err != nil {
+		return nil, err
+	}
+
+	err = unMarshalJson(res, &z)
+	if err != nil {
+		return nil, err
+	}
+
+	// The zone moves to a new path when it is renamed
+	z.apiPath = fmt.Sprintf(ZoneAPIPathFormat, url.PathEscape(z.name))
+	return z, nil
 }
 
 func (z *zone) ID() int {
@@ -84,11 +217,26 @@ func (z *zone) Description() string {
 	return z.description
 }
 
+func (z *zone) MachinesCount() int {
+	return z.machinesCount
+}
+
+func (z *zone) DevicesCount() int {
+	return z.devicesCount
+}
+
+func (z *zone) ControllersCount() int {
+	return z.controllersCount
+}
+
 func (z *zone) UnmarshalJSON(data []byte) error {
 	des := &struct {
-		ID          int    `json:"id"`
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		ID               int    `json:"id"`
+		Name             string `json:"name"`
+		Description      string `json:"description"`
+		MachinesCount    int    `json:"machines_count"`
+		DevicesCount     int    `json:"devices_count"`
+		ControllersCount int    `json:"controllers_count"`
 	}{}
 
 	err := json.Unmarshal(data, des)
@@ -99,6 +247,9 @@ func (z *zone) UnmarshalJSON(data []byte) error {
 	z.id = des.ID
 	z.name = des.Name
 	z.description = des.Description
+	z.machinesCount = des.MachinesCount
+	z.devicesCount = des.DevicesCount
+	z.controllersCount = des.ControllersCount
 
 	return nil
 }
@@ -34,4 +34,29 @@ func TestZones(t *testing.T) {
 		assert.NotNil(t, zones)
 		assert.NotEmpty(t, zones)
 	})
+
+	t.Run("create, update and delete zone", func(t *testing.T) {
+		res, err := c.Zones().Builder().
+			WithName("zone-test").
+			WithDescription("created by tests").
+			Create(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Equal(t, res.Name(), "zone-test")
+
+		res, err = res.Modifier().SetName("zone-renamed").Update(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, res.Name(), "zone-renamed")
+
+		res, err = c.Zones().ZoneByID(ctx, res.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, res.Description(), "created by tests")
+
+		res, err = c.Zones().Zone("zone-renamed").Get(ctx)
+		assert.Nil(t, err)
+		assert.Zero(t, res.MachinesCount())
+
+		err = res.Delete(ctx)
+		assert.Nil(t, err)
+	})
 }